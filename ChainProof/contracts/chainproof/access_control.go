@@ -1,7 +1,10 @@
 package main
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"strings"
 
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
 )
@@ -10,17 +13,18 @@ import (
 // ChainProof - Access Control
 // =============================================================================
 // MSP-based access control for organization-specific functions.
-// Ensures only authorized orgs can perform specific actions.
+// Ensures only authorized orgs can perform specific actions. The MSPs allowed
+// for each organization role are read from the on-ledger organization registry.
 // =============================================================================
 
-// Organization MSP IDs (must match MICROFAB config)
+// Default organization MSP IDs (must match MICROFAB config; the organization registry is authoritative)
 const (
 	WhistleblowersOrgMSP = "WhistleblowersOrgMSP"
 	VerifierOrgMSP       = "VerifierOrgMSP"
 	LegalOrgMSP          = "LegalOrgMSP"
 )
 
-// Private Data Collection Names
+// Default private data collection names (the organization registry is authoritative)
 const (
 	WhistleblowerPrivateCollection = "WhistleblowerPrivateCollection" // Notifications + Reputation
 	VerifierPrivateCollection      = "VerifierPrivateCollection"      // Technical notes
@@ -36,6 +40,26 @@ func GetClientOrgID(ctx contractapi.TransactionContextInterface) (string, error)
 	return clientOrgID, nil
 }
 
+// GetClientFingerprint returns a SHA256 fingerprint of the calling client's identity ID.
+// Used to tie ledger records to a concrete certificate without storing the certificate itself.
+func GetClientFingerprint(ctx contractapi.TransactionContextInterface) (string, error) {
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		return "", fmt.Errorf("failed to get client identity: %v", err)
+	}
+	hash := sha256.Sum256([]byte(clientID))
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// normalizeFingerprint validates an identity fingerprint (hex SHA256 of a certificate ID) and lowercases it
+func normalizeFingerprint(fingerprint string) (string, error) {
+	fingerprint = strings.ToLower(strings.TrimSpace(fingerprint))
+	if decoded, err := hex.DecodeString(fingerprint); err != nil || len(decoded) != sha256.Size {
+		return "", fmt.Errorf("invalid identity fingerprint %q: expected 64 hex characters", fingerprint)
+	}
+	return fingerprint, nil
+}
+
 // VerifyClientOrg checks if the caller belongs to the specified organization
 func VerifyClientOrg(ctx contractapi.TransactionContextInterface, allowedMSP string) error {
 	clientMSP, err := GetClientOrgID(ctx)
@@ -66,41 +90,59 @@ func VerifyClientOrgMultiple(ctx contractapi.TransactionContextInterface, allowe
 	return fmt.Errorf("access denied: caller MSP '%s' not in allowed list %v", clientMSP, allowedMSPs)
 }
 
-// RequireWhistleblowerOrg ensures caller is from WhistleblowersOrg
-func RequireWhistleblowerOrg(ctx contractapi.TransactionContextInterface) error {
-	return VerifyClientOrg(ctx, WhistleblowersOrgMSP)
+// requireOrgRole ensures caller is from an MSP the organization registry lists for role
+func requireOrgRole(ctx contractapi.TransactionContextInterface, role string) error {
+	registry, err := getOrgRegistry(ctx)
+	if err != nil {
+		return err
+	}
+	return VerifyClientOrgMultiple(ctx, registry.mspIDs(role))
 }
 
-// RequireVerifierOrg ensures caller is from VerifierOrg
-func RequireVerifierOrg(ctx contractapi.TransactionContextInterface) error {
-	return VerifyClientOrg(ctx, VerifierOrgMSP)
+// RequireWhistleblowerOrg ensures caller is from a submitter org (WhistleblowersOrg) and, when roles are given, holds one of them
+func RequireWhistleblowerOrg(ctx contractapi.TransactionContextInterface, roles ...string) error {
+	if err := requireOrgRole(ctx, OrgRoleSubmitter); err != nil {
+		return err
+	}
+	return RequireRole(ctx, roles...)
+}
+
+// RequireVerifierOrg ensures caller is from a verifier org and, when roles are given, holds one of them
+func RequireVerifierOrg(ctx contractapi.TransactionContextInterface, roles ...string) error {
+	if err := requireOrgRole(ctx, OrgRoleVerifier); err != nil {
+		return err
+	}
+	return RequireRole(ctx, roles...)
 }
 
-// RequireLegalOrg ensures caller is from LegalOrg
-func RequireLegalOrg(ctx contractapi.TransactionContextInterface) error {
-	return VerifyClientOrg(ctx, LegalOrgMSP)
+// RequireLegalOrg ensures caller is from a legal org and, when roles are given, holds one of them
+func RequireLegalOrg(ctx contractapi.TransactionContextInterface, roles ...string) error {
+	if err := requireOrgRole(ctx, OrgRoleLegal); err != nil {
+		return err
+	}
+	return RequireRole(ctx, roles...)
 }
 
-// RequireAnyOrg ensures caller is from any of the three organizations
+// RequireAnyOrg ensures caller is from any organization in the registry, auditors included
 func RequireAnyOrg(ctx contractapi.TransactionContextInterface) error {
-	return VerifyClientOrgMultiple(ctx, []string{
-		WhistleblowersOrgMSP,
-		VerifierOrgMSP,
-		LegalOrgMSP,
-	})
+	registry, err := getOrgRegistry(ctx)
+	if err != nil {
+		return err
+	}
+	return VerifyClientOrgMultiple(ctx, registry.allMSPIDs())
 }
 
-// IsWhistleblowerOrg checks if caller is from WhistleblowersOrg (non-throwing)
+// IsWhistleblowerOrg checks if caller is from a submitter org (non-throwing)
 func IsWhistleblowerOrg(ctx contractapi.TransactionContextInterface) bool {
 	return RequireWhistleblowerOrg(ctx) == nil
 }
 
-// IsVerifierOrg checks if caller is from VerifierOrg (non-throwing)
+// IsVerifierOrg checks if caller is from a verifier org (non-throwing)
 func IsVerifierOrg(ctx contractapi.TransactionContextInterface) bool {
 	return RequireVerifierOrg(ctx) == nil
 }
 
-// IsLegalOrg checks if caller is from LegalOrg (non-throwing)
+// IsLegalOrg checks if caller is from a legal org (non-throwing)
 func IsLegalOrg(ctx contractapi.TransactionContextInterface) bool {
 	return RequireLegalOrg(ctx) == nil
 }
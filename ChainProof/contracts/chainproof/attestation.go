@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// =============================================================================
+// ChainProof - Verifier Attestations
+// =============================================================================
+// Each VerifyIntegrity call records one attestation from a distinct verifier
+// identity under attestation~evidenceId~round~fingerprint. Once a round holds
+// VerificationQuorum attestations the outcome is decided: unanimous pass is
+// VERIFIED, unanimous fail is REJECTED, anything else is DISPUTED.
+// =============================================================================
+
+// attestationObjectType is the composite key object type for attestations
+const attestationObjectType = "attestation"
+
+// attestationRoundFormat zero-pads rounds so composite keys sort numerically
+const attestationRoundFormat = "%04d"
+
+// Verification outcomes
+const (
+	OutcomePending  = "PENDING"  // Quorum not reached yet
+	OutcomeVerified = "VERIFIED" // Quorum agreed the hash matches
+	OutcomeRejected = "REJECTED" // Quorum agreed the hash does not match
+	OutcomeDisputed = "DISPUTED" // Quorum disagreed
+)
+
+// attestationKey builds the composite key attestation~evidenceId~round~fingerprint
+func attestationKey(ctx contractapi.TransactionContextInterface, evidenceId string, round int, fingerprint string) (string, error) {
+	key, err := ctx.GetStub().CreateCompositeKey(attestationObjectType,
+		[]string{evidenceId, fmt.Sprintf(attestationRoundFormat, round), fingerprint})
+	if err != nil {
+		return "", fmt.Errorf("failed to create attestation key: %v", err)
+	}
+	return key, nil
+}
+
+// putAttestation stores an attestation, refusing a second one from the same identity in a round
+func putAttestation(ctx contractapi.TransactionContextInterface, attestation *VerificationAttestation) error {
+	key, err := attestationKey(ctx, attestation.EvidenceID, attestation.Round, attestation.VerifierFingerprint)
+	if err != nil {
+		return err
+	}
+
+	existing, err := ctx.GetStub().GetState(key)
+	if err != nil {
+		return fmt.Errorf("failed to read attestation: %v", err)
+	}
+	if existing != nil {
+		return fmt.Errorf("verifier identity %s already attested evidence %s in round %d",
+			attestation.VerifierFingerprint, attestation.EvidenceID, attestation.Round)
+	}
+
+	attestationJSON, err := json.Marshal(attestation)
+	if err != nil {
+		return fmt.Errorf("failed to marshal attestation: %v", err)
+	}
+	return ctx.GetStub().PutState(key, attestationJSON)
+}
+
+// getAttestations returns attestations for evidenceId, for one round or all rounds (round < 0)
+func getAttestations(ctx contractapi.TransactionContextInterface, evidenceId string, round int) ([]*VerificationAttestation, error) {
+	attributes := []string{evidenceId}
+	if round >= 0 {
+		attributes = append(attributes, fmt.Sprintf(attestationRoundFormat, round))
+	}
+
+	resultsIterator, err := ctx.GetStub().GetStateByPartialCompositeKey(attestationObjectType, attributes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attestations for %s: %v", evidenceId, err)
+	}
+	defer resultsIterator.Close()
+
+	attestations := []*VerificationAttestation{}
+	for resultsIterator.HasNext() {
+		queryResult, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		var attestation VerificationAttestation
+		if err := json.Unmarshal(queryResult.Value, &attestation); err != nil {
+			return nil, err
+		}
+		attestations = append(attestations, &attestation)
+	}
+
+	return attestations, nil
+}
+
+// decideOutcome evaluates a round's attestations against the quorum
+func decideOutcome(attestations []*VerificationAttestation, quorum int) string {
+	if len(attestations) < quorum {
+		return OutcomePending
+	}
+
+	passed := 0
+	for _, attestation := range attestations {
+		if attestation.Passed {
+			passed++
+		}
+	}
+
+	switch passed {
+	case len(attestations):
+		return OutcomeVerified
+	case 0:
+		return OutcomeRejected
+	default:
+		return OutcomeDisputed
+	}
+}
+
+// checkAppealVerifier rejects verifier identities that attested in any round before the appeal
+func checkAppealVerifier(ctx contractapi.TransactionContextInterface, evidence *Evidence, fingerprint string) error {
+	earlier, err := getAttestations(ctx, evidence.EvidenceID, -1)
+	if err != nil {
+		return err
+	}
+
+	for _, attestation := range earlier {
+		if attestation.Round < evidence.AppealRound && attestation.VerifierFingerprint == fingerprint {
+			return fmt.Errorf("verifier identity %s attested evidence %s before its appeal; a different verifier must re-verify it",
+				fingerprint, evidence.EvidenceID)
+		}
+	}
+	return nil
+}
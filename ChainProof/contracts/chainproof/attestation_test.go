@@ -0,0 +1,39 @@
+package main
+
+import "testing"
+
+func TestDecideOutcome(t *testing.T) {
+	attest := func(results ...bool) []*VerificationAttestation {
+		attestations := []*VerificationAttestation{}
+		for _, passed := range results {
+			attestations = append(attestations, &VerificationAttestation{Passed: passed})
+		}
+		return attestations
+	}
+
+	tests := []struct {
+		name         string
+		attestations []*VerificationAttestation
+		quorum       int
+		want         string
+	}{
+		{"no attestations", attest(), 1, OutcomePending},
+		{"single pass", attest(true), 1, OutcomeVerified},
+		{"single fail", attest(false), 1, OutcomeRejected},
+		{"below quorum", attest(true), 2, OutcomePending},
+		{"below quorum with a failure", attest(false, true), 3, OutcomePending},
+		{"unanimous pass", attest(true, true), 2, OutcomeVerified},
+		{"unanimous fail", attest(false, false), 2, OutcomeRejected},
+		{"split decision", attest(true, false), 2, OutcomeDisputed},
+		{"quorum lowered below attestations", attest(true, true, false), 2, OutcomeDisputed},
+		{"quorum lowered, unanimous", attest(true, true, true), 1, OutcomeVerified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := decideOutcome(tt.attestations, tt.quorum); got != tt.want {
+				t.Fatalf("decideOutcome = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
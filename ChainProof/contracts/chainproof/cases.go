@@ -0,0 +1,480 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// =============================================================================
+// ChainProof - Case Management
+// =============================================================================
+// LegalOrg groups evidence into cases (investigations). A case is stored under
+// case~caseId and keeps its evidence IDs in link order; case_evidence~evidenceId~caseId
+// lets queries find every case that references an evidence item. Each case
+// event is also written to the custody log of every evidence item it touches.
+// =============================================================================
+
+// Composite key object types
+const (
+	caseObjectType         = "case"
+	caseEvidenceObjectType = "case_evidence"
+)
+
+// OpenCase starts a new investigation
+// assigneeFingerprint is the certificate ID hash of the responsible reviewer; empty assigns the caller.
+func (c *LegalContract) OpenCase(
+	ctx contractapi.TransactionContextInterface,
+	caseId string,
+	title string,
+	jurisdiction string,
+	assigneeFingerprint string,
+) error {
+	// Access control
+	if err := RequireLegalOrg(ctx, RoleSupervisor); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(caseId) == "" || strings.TrimSpace(title) == "" || strings.TrimSpace(jurisdiction) == "" {
+		return fmt.Errorf("caseId, title and jurisdiction are required")
+	}
+
+	existing, err := readCase(ctx, caseId)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("case %s already exists", caseId)
+	}
+
+	fingerprint, err := GetClientFingerprint(ctx)
+	if err != nil {
+		return err
+	}
+	assignee := fingerprint
+	if assigneeFingerprint != "" {
+		if assignee, err = normalizeFingerprint(assigneeFingerprint); err != nil {
+			return err
+		}
+	}
+
+	timestamp, err := getTxTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+
+	return putCase(ctx, &Case{
+		DocType:      "case",
+		CaseID:       caseId,
+		Title:        title,
+		Status:       CaseStatusOpen,
+		Jurisdiction: jurisdiction,
+		EvidenceIDs:  []string{},
+		AssignedTo:   assignee,
+		OpenedBy:     fingerprint,
+		OpenedAt:     timestamp,
+		UpdatedAt:    timestamp,
+	})
+}
+
+// CloseCase ends an investigation; a closed case can no longer change
+func (c *LegalContract) CloseCase(
+	ctx contractapi.TransactionContextInterface,
+	caseId string,
+	resolution string,
+) error {
+	// Access control
+	if err := RequireLegalOrg(ctx, RoleSupervisor); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(resolution) == "" {
+		return fmt.Errorf("resolution is required to close a case")
+	}
+
+	legalCase, err := getOpenCase(ctx, caseId)
+	if err != nil {
+		return err
+	}
+
+	timestamp, err := getTxTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+
+	legalCase.Status = CaseStatusClosed
+	legalCase.Resolution = resolution
+	legalCase.ClosedAt = timestamp
+	legalCase.UpdatedAt = timestamp
+
+	if err := putCase(ctx, legalCase); err != nil {
+		return err
+	}
+
+	return appendCaseCustodyLog(ctx, legalCase, ActionCaseClose,
+		fmt.Sprintf("Case %s closed | Resolution: %s", caseId, resolution), timestamp)
+}
+
+// ReassignCase hands an open case to another legal reviewer
+func (c *LegalContract) ReassignCase(
+	ctx contractapi.TransactionContextInterface,
+	caseId string,
+	assigneeFingerprint string,
+	reason string,
+) error {
+	// Access control
+	if err := RequireLegalOrg(ctx, RoleSupervisor); err != nil {
+		return err
+	}
+
+	assignee, err := normalizeFingerprint(assigneeFingerprint)
+	if err != nil {
+		return err
+	}
+
+	legalCase, err := getOpenCase(ctx, caseId)
+	if err != nil {
+		return err
+	}
+	if legalCase.AssignedTo == assignee {
+		return fmt.Errorf("case %s is already assigned to %s", caseId, assignee)
+	}
+
+	timestamp, err := getTxTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+
+	previous := legalCase.AssignedTo
+	legalCase.AssignedTo = assignee
+	legalCase.UpdatedAt = timestamp
+
+	if err := putCase(ctx, legalCase); err != nil {
+		return err
+	}
+
+	description := fmt.Sprintf("Case %s reassigned from %s to %s", caseId, previous, assignee)
+	if reason != "" {
+		description += fmt.Sprintf(" | Reason: %s", reason)
+	}
+	return appendCaseCustodyLog(ctx, legalCase, ActionCaseReassign, description, timestamp)
+}
+
+// LinkEvidenceToCase adds an evidence item to an open case
+func (c *LegalContract) LinkEvidenceToCase(
+	ctx contractapi.TransactionContextInterface,
+	caseId string,
+	evidenceId string,
+) error {
+	// Access control
+	if err := RequireLegalOrg(ctx, RoleReviewer, RoleSupervisor); err != nil {
+		return err
+	}
+
+	legalCase, err := getOpenCase(ctx, caseId)
+	if err != nil {
+		return err
+	}
+
+	evidence, err := getEvidence(ctx, evidenceId)
+	if err != nil {
+		return err
+	}
+	if err := requireOpenEvidence(evidence); err != nil {
+		return err
+	}
+
+	for _, linked := range legalCase.EvidenceIDs {
+		if linked == evidenceId {
+			return fmt.Errorf("evidence %s is already linked to case %s", evidenceId, caseId)
+		}
+	}
+
+	timestamp, err := getTxTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+
+	legalCase.EvidenceIDs = append(legalCase.EvidenceIDs, evidenceId)
+	legalCase.UpdatedAt = timestamp
+
+	if err := putCase(ctx, legalCase); err != nil {
+		return err
+	}
+
+	indexKey, err := caseEvidenceKey(ctx, evidenceId, caseId)
+	if err != nil {
+		return err
+	}
+	if err := ctx.GetStub().PutState(indexKey, []byte{0x00}); err != nil {
+		return fmt.Errorf("failed to index case evidence: %v", err)
+	}
+
+	return appendCustodyLog(ctx, evidenceId, ActionCaseLink,
+		fmt.Sprintf("Linked to case %s (%s, %s)", caseId, legalCase.Title, legalCase.Jurisdiction), timestamp)
+}
+
+// UnlinkEvidenceFromCase removes an evidence item from an open case
+func (c *LegalContract) UnlinkEvidenceFromCase(
+	ctx contractapi.TransactionContextInterface,
+	caseId string,
+	evidenceId string,
+	reason string,
+) error {
+	// Access control
+	if err := RequireLegalOrg(ctx, RoleReviewer, RoleSupervisor); err != nil {
+		return err
+	}
+
+	legalCase, err := getOpenCase(ctx, caseId)
+	if err != nil {
+		return err
+	}
+
+	remaining := []string{}
+	for _, linked := range legalCase.EvidenceIDs {
+		if linked != evidenceId {
+			remaining = append(remaining, linked)
+		}
+	}
+	if len(remaining) == len(legalCase.EvidenceIDs) {
+		return fmt.Errorf("evidence %s is not linked to case %s", evidenceId, caseId)
+	}
+
+	timestamp, err := getTxTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+
+	legalCase.EvidenceIDs = remaining
+	legalCase.UpdatedAt = timestamp
+
+	if err := putCase(ctx, legalCase); err != nil {
+		return err
+	}
+
+	indexKey, err := caseEvidenceKey(ctx, evidenceId, caseId)
+	if err != nil {
+		return err
+	}
+	if err := deleteState(ctx, indexKey); err != nil {
+		return fmt.Errorf("failed to remove case evidence index: %v", err)
+	}
+
+	description := fmt.Sprintf("Unlinked from case %s", caseId)
+	if reason != "" {
+		description += fmt.Sprintf(" | Reason: %s", reason)
+	}
+	return appendCustodyLog(ctx, evidenceId, ActionCaseUnlink, description, timestamp)
+}
+
+// GetCase retrieves a case by ID
+func (c *QueryContract) GetCase(
+	ctx contractapi.TransactionContextInterface,
+	caseId string,
+) (*Case, error) {
+	if err := RequireAnyOrg(ctx); err != nil {
+		return nil, err
+	}
+
+	return getCase(ctx, caseId)
+}
+
+// GetCaseEvidence lists the evidence linked to a case, in link order
+func (c *QueryContract) GetCaseEvidence(
+	ctx contractapi.TransactionContextInterface,
+	caseId string,
+) ([]*Evidence, error) {
+	if err := RequireAnyOrg(ctx); err != nil {
+		return nil, err
+	}
+
+	legalCase, err := getCase(ctx, caseId)
+	if err != nil {
+		return nil, err
+	}
+
+	records := []*Evidence{}
+	for _, evidenceId := range legalCase.EvidenceIDs {
+		evidence, err := getEvidence(ctx, evidenceId)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, evidence)
+	}
+	return records, nil
+}
+
+// GetCasesForEvidence lists every case that references an evidence item
+func (c *QueryContract) GetCasesForEvidence(
+	ctx contractapi.TransactionContextInterface,
+	evidenceId string,
+) ([]*Case, error) {
+	if err := RequireAnyOrg(ctx); err != nil {
+		return nil, err
+	}
+
+	resultsIterator, err := ctx.GetStub().GetStateByPartialCompositeKey(caseEvidenceObjectType, []string{evidenceId})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cases for %s: %v", evidenceId, err)
+	}
+	defer resultsIterator.Close()
+
+	cases := []*Case{}
+	for resultsIterator.HasNext() {
+		queryResult, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		_, attributes, err := ctx.GetStub().SplitCompositeKey(queryResult.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to split case evidence key: %v", err)
+		}
+		legalCase, err := getCase(ctx, attributes[1])
+		if err != nil {
+			return nil, err
+		}
+		cases = append(cases, legalCase)
+	}
+	return cases, nil
+}
+
+// QueryCasesByStatus lists cases by status (OPEN or CLOSED) with pagination
+func (c *QueryContract) QueryCasesByStatus(
+	ctx contractapi.TransactionContextInterface,
+	status string,
+	pageSize int32,
+	bookmark string,
+) (*CaseQueryResult, error) {
+	if err := RequireAnyOrg(ctx); err != nil {
+		return nil, err
+	}
+
+	queryString, err := NewSelector("case").Equals("status", status).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	resultsIterator, responseMetadata, err := ctx.GetStub().GetQueryResultWithPagination(queryString, pageSize, bookmark)
+	if err != nil {
+		return nil, err
+	}
+	defer resultsIterator.Close()
+
+	records := []*Case{}
+	for resultsIterator.HasNext() {
+		queryResult, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		var legalCase Case
+		if err := json.Unmarshal(queryResult.Value, &legalCase); err != nil {
+			return nil, err
+		}
+		records = append(records, &legalCase)
+	}
+
+	return &CaseQueryResult{
+		Records:             records,
+		FetchedRecordsCount: len(records),
+		Bookmark:            responseMetadata.Bookmark,
+	}, nil
+}
+
+// appendCaseCustodyLog records a case event in the custody log of every linked evidence item
+func appendCaseCustodyLog(
+	ctx contractapi.TransactionContextInterface,
+	legalCase *Case,
+	action string,
+	description string,
+	timestamp int64,
+) error {
+	for _, evidenceId := range legalCase.EvidenceIDs {
+		if err := appendCustodyLog(ctx, evidenceId, action, description, timestamp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// caseKey builds the composite key case~caseId
+func caseKey(ctx contractapi.TransactionContextInterface, caseId string) (string, error) {
+	key, err := ctx.GetStub().CreateCompositeKey(caseObjectType, []string{caseId})
+	if err != nil {
+		return "", fmt.Errorf("failed to create case key: %v", err)
+	}
+	return key, nil
+}
+
+// caseEvidenceKey builds the index key case_evidence~evidenceId~caseId
+func caseEvidenceKey(ctx contractapi.TransactionContextInterface, evidenceId string, caseId string) (string, error) {
+	key, err := ctx.GetStub().CreateCompositeKey(caseEvidenceObjectType, []string{evidenceId, caseId})
+	if err != nil {
+		return "", fmt.Errorf("failed to create case evidence key: %v", err)
+	}
+	return key, nil
+}
+
+// readCase returns the case, or nil if it does not exist
+func readCase(ctx contractapi.TransactionContextInterface, caseId string) (*Case, error) {
+	key, err := caseKey(ctx, caseId)
+	if err != nil {
+		return nil, err
+	}
+
+	caseJSON, err := readState(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read case %s: %v", caseId, err)
+	}
+	if caseJSON == nil {
+		return nil, nil
+	}
+
+	var legalCase Case
+	if err := json.Unmarshal(caseJSON, &legalCase); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal case: %v", err)
+	}
+	return &legalCase, nil
+}
+
+// getCase returns the case or an error if it does not exist
+func getCase(ctx contractapi.TransactionContextInterface, caseId string) (*Case, error) {
+	legalCase, err := readCase(ctx, caseId)
+	if err != nil {
+		return nil, err
+	}
+	if legalCase == nil {
+		return nil, fmt.Errorf("case %s does not exist", caseId)
+	}
+	return legalCase, nil
+}
+
+// getOpenCase returns the case, refusing closed ones
+func getOpenCase(ctx contractapi.TransactionContextInterface, caseId string) (*Case, error) {
+	legalCase, err := getCase(ctx, caseId)
+	if err != nil {
+		return nil, err
+	}
+	if legalCase.Status != CaseStatusOpen {
+		return nil, fmt.Errorf("case %s is %s and can no longer change", caseId, legalCase.Status)
+	}
+	return legalCase, nil
+}
+
+// putCase stores a case
+func putCase(ctx contractapi.TransactionContextInterface, legalCase *Case) error {
+	key, err := caseKey(ctx, legalCase.CaseID)
+	if err != nil {
+		return err
+	}
+
+	caseJSON, err := json.Marshal(legalCase)
+	if err != nil {
+		return fmt.Errorf("failed to marshal case: %v", err)
+	}
+	return writeState(ctx, key, caseJSON)
+}
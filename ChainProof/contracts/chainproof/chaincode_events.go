@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/chainproof/chaincode/events"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// =============================================================================
+// ChainProof - Chaincode Events
+// =============================================================================
+// Clients (fabric_gateway, frontend) subscribe to chaincode events instead of
+// polling. The schema lives in the importable events package. Fabric keeps
+// only the last SetEvent of a transaction, so each change is appended to the
+// transaction's list on ChainProofContext and the whole envelope is emitted
+// again; the event name is the type of the first change.
+// =============================================================================
+
+// transitionEvents maps state machine actions to the event they emit
+// Attestations and assignments emit none; COMPLETE_REVIEW is emitted by ReviewEvidence with the verdict.
+var transitionEvents = map[string]string{
+	TransitionVerifyPass:    events.EvidenceVerified,
+	TransitionVerifyFail:    events.EvidenceRejected,
+	TransitionVerifyDispute: events.EvidenceDisputed,
+	TransitionAppeal:        events.EvidenceAppealed,
+	TransitionWithdraw:      events.EvidenceWithdrawn,
+	TransitionSupersede:     events.EvidenceSuperseded,
+	TransitionStartReview:   events.ReviewStarted,
+	TransitionReopenReview:  events.ReviewReopened,
+	TransitionHashMismatch:  events.HashMismatchReported,
+	TransitionRequestExport: events.ExportRequested,
+	TransitionExport:        events.EvidenceExported,
+}
+
+// emitEvent adds a change to the transaction's event envelope and emits it
+func emitEvent(ctx contractapi.TransactionContextInterface, event *events.Event) error {
+	timestamp, err := getTxTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+	actorOrg, err := GetClientOrgID(ctx)
+	if err != nil {
+		return err
+	}
+	event.ActorOrg = actorOrg
+	event.TxID = ctx.GetStub().GetTxID()
+
+	pending := []*events.Event{event}
+	if cpCtx, ok := ctx.(*ChainProofContext); ok {
+		cpCtx.emitted = append(cpCtx.emitted, event)
+		pending = cpCtx.emitted
+	}
+
+	payload, err := json.Marshal(events.Envelope{
+		SchemaVersion: events.SchemaVersion,
+		TxID:          event.TxID,
+		ActorOrg:      actorOrg,
+		Timestamp:     timestamp,
+		Events:        pending,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal chaincode event: %v", err)
+	}
+	if err := ctx.GetStub().SetEvent(pending[0].Type, payload); err != nil {
+		return fmt.Errorf("failed to set chaincode event: %v", err)
+	}
+	return nil
+}
+
+// emitStatusEvent emits an event for a change that leaves the evidence status as it is
+func emitStatusEvent(ctx contractapi.TransactionContextInterface, eventType string, evidence *Evidence) error {
+	return emitEvent(ctx, &events.Event{
+		Type:       eventType,
+		EvidenceID: evidence.EvidenceID,
+		OldStatus:  evidence.Status,
+		NewStatus:  evidence.Status,
+	})
+}
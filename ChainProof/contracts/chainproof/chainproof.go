@@ -1,12 +1,11 @@
 package main
 
 import (
-	"crypto/sha256"
-	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"time"
+	"strings"
 
+	"github.com/chainproof/chaincode/events"
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
 )
 
@@ -32,13 +31,23 @@ type WhistleblowerContract struct {
 	contractapi.Contract
 }
 
-// InitLedger initializes the chaincode
+// InitLedger initializes the chaincode and writes the default organization registry and parameters
 func (c *WhistleblowerContract) InitLedger(ctx contractapi.TransactionContextInterface) error {
+	if err := initOrgRegistry(ctx); err != nil {
+		return err
+	}
+	if err := initParams(ctx); err != nil {
+		return err
+	}
 	fmt.Println("ChainProof chaincode initialized successfully")
 	return nil
 }
 
 // SubmitEvidence creates a new evidence record on the public ledger
+// publicKey (PEM or base64 DER, ECDSA P-256 or Ed25519) must hash to publicKeyHash,
+// and signature must verify over SubmissionSignedMessage(evidenceId, ipfsCid, fileHash,
+// supersedesEvidenceId) with the matching private key. supersedesEvidenceId is optional: when set, the new record
+// becomes the next version of that evidence (submitted with the same key), which is marked SUPERSEDED.
 func (c *WhistleblowerContract) SubmitEvidence(
 	ctx contractapi.TransactionContextInterface,
 	evidenceId string,
@@ -49,7 +58,9 @@ func (c *WhistleblowerContract) SubmitEvidence(
 	category string,
 	description string,
 	publicKeyHash string,
+	publicKey string,
 	signature string,
+	supersedesEvidenceId string,
 ) error {
 	// Access control: only WhistleblowersOrg can submit
 	if err := RequireWhistleblowerOrg(ctx); err != nil {
@@ -63,6 +74,29 @@ func (c *WhistleblowerContract) SubmitEvidence(
 	if signature == "" {
 		return fmt.Errorf("signature is required to prove ownership of keypair")
 	}
+	if fileHash == "" {
+		return fmt.Errorf("fileHash is required")
+	}
+	if err := validateSignedField("evidenceId", evidenceId); err != nil {
+		return err
+	}
+	if err := validateSignedField("ipfsCid", ipfsCid); err != nil {
+		return err
+	}
+
+	// Bind the public key to the pseudonym and prove possession of the private key
+	if _, err := verifyPseudonymousSignature(publicKey, publicKeyHash,
+		SubmissionSignedMessage(evidenceId, ipfsCid, fileHash, supersedesEvidenceId), signature); err != nil {
+		return err
+	}
+
+	params, err := getParams(ctx)
+	if err != nil {
+		return err
+	}
+	if err := validateCategory(params, category); err != nil {
+		return err
+	}
 
 	// Check if evidence already exists
 	exists, err := evidenceExists(ctx, evidenceId)
@@ -73,18 +107,10 @@ func (c *WhistleblowerContract) SubmitEvidence(
 		return fmt.Errorf("evidence %s already exists", evidenceId)
 	}
 
-	// Get caller org for custody log
-	callerOrg, _ := GetClientOrgID(ctx)
-	timestamp := time.Now().Unix()
-
-	// Create initial custody log entry
-	custodyLog := []CustodyLog{
-		{
-			Action:      ActionSubmit,
-			ActorOrg:    callerOrg,
-			Timestamp:   timestamp,
-			Description: "Evidence submitted anonymously via cryptographic keypair",
-		},
+	// Deterministic timestamp shared by all endorsing peers
+	timestamp, err := getTxTimestamp(ctx)
+	if err != nil {
+		return err
 	}
 
 	// Create evidence record with pseudonymous identity
@@ -100,80 +126,84 @@ func (c *WhistleblowerContract) SubmitEvidence(
 		SubmittedAt:     timestamp,
 		Status:          StatusSubmitted,
 		IntegrityStatus: IntegrityPending,
-		CustodyLog:      custodyLog,
 		PublicKeyHash:   publicKeyHash,
+		PublicKey:       publicKey,
 		Signature:       signature,
 	}
 
+	// Announce the submission first; superseding the previous version follows in the same event
+	if err := emitEvent(ctx, &events.Event{
+		Type:       events.EvidenceSubmitted,
+		EvidenceID: evidenceId,
+		NewStatus:  StatusSubmitted,
+	}); err != nil {
+		return err
+	}
+
+	// Link into the previous version's chain before storing
+	custodyDescription := "Evidence submitted anonymously via cryptographic keypair"
+	if supersedesEvidenceId != "" {
+		if err := supersedeEvidence(ctx, supersedesEvidenceId, &evidence, timestamp); err != nil {
+			return err
+		}
+		custodyDescription += fmt.Sprintf(" | Supersedes %s", supersedesEvidenceId)
+	}
+
 	// Store on public ledger
 	evidenceJSON, err := json.Marshal(evidence)
 	if err != nil {
 		return fmt.Errorf("failed to marshal evidence: %v", err)
 	}
 
-	if err := ctx.GetStub().PutState(evidenceId, evidenceJSON); err != nil {
+	if err := writeState(ctx, evidenceId, evidenceJSON); err != nil {
 		return fmt.Errorf("failed to store evidence: %v", err)
 	}
 
-	// Update reputation - increment total submissions
-	if err := c.updateReputationOnSubmit(ctx, publicKeyHash, timestamp); err != nil {
-		// Log but don't fail - reputation is secondary
-		fmt.Printf("Warning: failed to update reputation: %v\n", err)
-	}
-
-	return nil
-}
-
-// updateReputationOnSubmit updates reputation when new evidence is submitted
-func (c *WhistleblowerContract) updateReputationOnSubmit(ctx contractapi.TransactionContextInterface, publicKeyHash string, timestamp int64) error {
-	reputationKey := "reputation_" + publicKeyHash
-	
-	// Try to get existing reputation
-	reputationJSON, err := ctx.GetStub().GetPrivateData(WhistleblowerPrivateCollection, reputationKey)
-	
-	var reputation Reputation
-	if err != nil || reputationJSON == nil {
-		// First submission - create new reputation
-		reputation = Reputation{
-			DocType:             "reputation",
-			PublicKeyHash:       publicKeyHash,
-			TotalSubmissions:    1,
-			VerifiedSubmissions: 0,
-			RejectedSubmissions: 0,
-			ExportedSubmissions: 0,
-			TrustScore:          50, // Start at neutral
-			FirstSubmissionAt:   timestamp,
-			LastSubmissionAt:    timestamp,
-			LastUpdatedAt:       timestamp,
-		}
-	} else {
-		if err := json.Unmarshal(reputationJSON, &reputation); err != nil {
-			return err
-		}
-		reputation.TotalSubmissions++
-		reputation.LastSubmissionAt = timestamp
-		reputation.LastUpdatedAt = timestamp
+	// Start the custody chain
+	if err := appendCustodyLog(ctx, evidenceId, ActionSubmit, custodyDescription, timestamp); err != nil {
+		return err
 	}
 
-	reputationBytes, err := json.Marshal(reputation)
-	if err != nil {
-		return err
+	// Update reputation - increment total submissions
+	if _, err := applyReputationEvent(ctx, publicKeyHash, reputationEvent{
+		EvidenceID: evidenceId,
+		Cause:      ReputationCauseSubmitted,
+		Detail:     "Evidence submitted",
+	}, timestamp); err != nil {
+		return fmt.Errorf("failed to update reputation: %v", err)
 	}
 
-	return ctx.GetStub().PutPrivateData(WhistleblowerPrivateCollection, reputationKey, reputationBytes)
+	return nil
 }
 
 // SubmitBulkEvidence submits multiple evidence items in a single transaction
+// Like SubmitEvidence, publicKey must hash to publicKeyHash; signature must verify over
+// BulkSubmissionSignedMessage(bulkSubmissionId, items), which binds every item's evidenceId,
+// ipfsCid and fileHash.
 func (c *WhistleblowerContract) SubmitBulkEvidence(
 	ctx contractapi.TransactionContextInterface,
 	bulkSubmissionId string,
 	itemsJSON string,
+	publicKeyHash string,
+	publicKey string,
+	signature string,
 ) (*BulkSubmissionResult, error) {
 	// Access control: only WhistleblowersOrg can submit
 	if err := RequireWhistleblowerOrg(ctx); err != nil {
 		return nil, err
 	}
 
+	if publicKeyHash == "" {
+		return nil, fmt.Errorf("publicKeyHash is required for anonymous identity")
+	}
+	if signature == "" {
+		return nil, fmt.Errorf("signature is required to prove ownership of keypair")
+	}
+
+	if err := validateSignedField("bulkSubmissionId", bulkSubmissionId); err != nil {
+		return nil, err
+	}
+
 	// Parse bulk items
 	var items []BulkEvidenceItem
 	if err := json.Unmarshal([]byte(itemsJSON), &items); err != nil {
@@ -183,13 +213,40 @@ func (c *WhistleblowerContract) SubmitBulkEvidence(
 	if len(items) == 0 {
 		return nil, fmt.Errorf("bulk submission must contain at least one item")
 	}
+	for idx, item := range items {
+		if item.FileHash == "" {
+			return nil, fmt.Errorf("item %d (%s): fileHash is required", idx, item.EvidenceID)
+		}
+		if err := validateSignedField("evidenceId", item.EvidenceID); err != nil {
+			return nil, fmt.Errorf("item %d: %v", idx, err)
+		}
+		if err := validateSignedField("ipfsCid", item.IPFSCID); err != nil {
+			return nil, fmt.Errorf("item %d (%s): %v", idx, item.EvidenceID, err)
+		}
+	}
 
-	callerOrg, _ := GetClientOrgID(ctx)
-	timestamp := time.Now().Unix()
+	// One signature covers the whole batch
+	if _, err := verifyPseudonymousSignature(publicKey, publicKeyHash,
+		BulkSubmissionSignedMessage(bulkSubmissionId, items), signature); err != nil {
+		return nil, err
+	}
+
+	timestamp, err := getTxTimestamp(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := getParams(ctx)
+	if err != nil {
+		return nil, err
+	}
 	var evidenceIDs []string
 
 	// Process each item
 	for idx, item := range items {
+		if err := validateCategory(params, item.Category); err != nil {
+			return nil, fmt.Errorf("item %d (%s): %v", idx, item.EvidenceID, err)
+		}
+
 		// Check if evidence already exists
 		exists, err := evidenceExists(ctx, item.EvidenceID)
 		if err != nil {
@@ -199,16 +256,6 @@ func (c *WhistleblowerContract) SubmitBulkEvidence(
 			return nil, fmt.Errorf("evidence %s already exists", item.EvidenceID)
 		}
 
-		// Create custody log for bulk submission
-		custodyLog := []CustodyLog{
-			{
-				Action:      ActionBulkSubmit,
-				ActorOrg:    callerOrg,
-				Timestamp:   timestamp,
-				Description: fmt.Sprintf("Bulk submission %s - item %d of %d", bulkSubmissionId, idx+1, len(items)),
-			},
-		}
-
 		// Create evidence record
 		evidence := Evidence{
 			DocType:          "evidence",
@@ -221,7 +268,9 @@ func (c *WhistleblowerContract) SubmitBulkEvidence(
 			SubmittedAt:      timestamp,
 			Status:           StatusSubmitted,
 			IntegrityStatus:  IntegrityPending,
-			CustodyLog:       custodyLog,
+			PublicKeyHash:    publicKeyHash,
+			PublicKey:        publicKey,
+			Signature:        signature,
 			BulkSubmissionID: bulkSubmissionId,
 			BulkIndex:        idx,
 		}
@@ -232,10 +281,33 @@ func (c *WhistleblowerContract) SubmitBulkEvidence(
 			return nil, fmt.Errorf("failed to marshal evidence %s: %v", item.EvidenceID, err)
 		}
 
-		if err := ctx.GetStub().PutState(item.EvidenceID, evidenceJSON); err != nil {
+		if err := writeState(ctx, item.EvidenceID, evidenceJSON); err != nil {
 			return nil, fmt.Errorf("failed to store evidence %s: %v", item.EvidenceID, err)
 		}
 
+		// Start the custody chain for this item
+		if err := appendCustodyLog(ctx, item.EvidenceID, ActionBulkSubmit,
+			fmt.Sprintf("Bulk submission %s - item %d of %d", bulkSubmissionId, idx+1, len(items)), timestamp); err != nil {
+			return nil, err
+		}
+
+		if err := emitEvent(ctx, &events.Event{
+			Type:             events.EvidenceBulkSubmitted,
+			EvidenceID:       item.EvidenceID,
+			NewStatus:        StatusSubmitted,
+			BulkSubmissionID: bulkSubmissionId,
+		}); err != nil {
+			return nil, err
+		}
+
+		if _, err := applyReputationEvent(ctx, publicKeyHash, reputationEvent{
+			EvidenceID: item.EvidenceID,
+			Cause:      ReputationCauseSubmitted,
+			Detail:     fmt.Sprintf("Evidence submitted in bulk submission %s", bulkSubmissionId),
+		}, timestamp); err != nil {
+			return nil, fmt.Errorf("failed to update reputation: %v", err)
+		}
+
 		evidenceIDs = append(evidenceIDs, item.EvidenceID)
 	}
 
@@ -262,39 +334,209 @@ func (c *WhistleblowerContract) UpdatePolygonAnchor(
 	if err != nil {
 		return err
 	}
+	if err := requireOpenEvidence(evidence); err != nil {
+		return err
+	}
 
-	callerOrg, _ := GetClientOrgID(ctx)
-	timestamp := time.Now().Unix()
+	timestamp, err := getTxTimestamp(ctx)
+	if err != nil {
+		return err
+	}
 
 	// Update Polygon anchor info
 	evidence.PolygonTxHash = polygonTxHash
 	evidence.PolygonAnchorAt = timestamp
 
+	if err := putEvidence(ctx, evidence); err != nil {
+		return err
+	}
+
 	// Add custody log
-	evidence.CustodyLog = append(evidence.CustodyLog, CustodyLog{
-		Action:      ActionAnchor,
-		ActorOrg:    callerOrg,
-		Timestamp:   timestamp,
-		Description: fmt.Sprintf("Anchored to Polygon: %s", polygonTxHash),
-	})
+	if err := appendCustodyLog(ctx, evidenceId, ActionAnchor, fmt.Sprintf("Anchored to Polygon: %s", polygonTxHash), timestamp); err != nil {
+		return err
+	}
+	return emitStatusEvent(ctx, events.AnchorRecorded, evidence)
+}
+
+// AppealRejection lets the key holder contest a REJECTED verification outcome
+// The caller signs BuildKeyChallenge(APPEAL_REJECTION, hash, ReasonChallengeSubject(evidenceId, reason), challengeTimestamp).
+// Evidence moves to APPEALED and a new attestation round opens, which only verifier identities
+// that did not attest before the appeal may join. Each evidence item can be appealed once.
+func (c *WhistleblowerContract) AppealRejection(
+	ctx contractapi.TransactionContextInterface,
+	evidenceId string,
+	reason string,
+	publicKey string,
+	challengeTimestamp int64,
+	signature string,
+) error {
+	// Access control
+	if err := RequireWhistleblowerOrg(ctx); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(reason) == "" {
+		return fmt.Errorf("appeal reason is required")
+	}
+
+	publicKeyHash, err := verifyKeyOwnership(ctx, publicKey, ChallengeAppealRejection,
+		ReasonChallengeSubject(evidenceId, reason), challengeTimestamp, signature)
+	if err != nil {
+		return err
+	}
+
+	evidence, err := getEvidence(ctx, evidenceId)
+	if err != nil {
+		return err
+	}
+
+	// Only the submitter may appeal
+	if !strings.EqualFold(evidence.PublicKeyHash, publicKeyHash) {
+		return fmt.Errorf("evidence %s was not submitted with this key", evidenceId)
+	}
+	if evidence.AppealStatus != "" {
+		return fmt.Errorf("evidence %s has already been appealed (appeal %s)", evidenceId, evidence.AppealStatus)
+	}
+
+	if _, err := applyTransition(ctx, evidence, TransitionAppeal); err != nil {
+		return err
+	}
+
+	timestamp, err := getTxTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Open a fresh attestation round for the appeal
+	evidence.IntegrityStatus = IntegrityPending
+	evidence.VerificationRound++
+	evidence.AppealRound = evidence.VerificationRound
+	evidence.AppealStatus = AppealPending
+	evidence.AppealReason = reason
+	evidence.AppealedAt = timestamp
+
+	if err := putEvidence(ctx, evidence); err != nil {
+		return err
+	}
+
+	// Add custody log
+	return appendCustodyLog(ctx, evidenceId, ActionAppeal,
+		fmt.Sprintf("Rejection appealed by submitter, re-verification round %d opened | Reason: %s", evidence.AppealRound, reason), timestamp)
+}
+
+// WithdrawEvidence lets the key holder retract a submission, e.g. if they fear identification
+// The caller signs BuildKeyChallenge(WITHDRAW_EVIDENCE, hash, ReasonChallengeSubject(evidenceId, reason), challengeTimestamp);
+// reason may be empty. Evidence moves to WITHDRAWN, which ends the workflow. Exported evidence
+// cannot be withdrawn. VerifierOrg and LegalOrg are notified, and the withdrawal is counted
+// separately in the submitter's reputation (it is not a rejection).
+func (c *WhistleblowerContract) WithdrawEvidence(
+	ctx contractapi.TransactionContextInterface,
+	evidenceId string,
+	reason string,
+	publicKey string,
+	challengeTimestamp int64,
+	signature string,
+) error {
+	// Access control
+	if err := RequireWhistleblowerOrg(ctx); err != nil {
+		return err
+	}
+
+	publicKeyHash, err := verifyKeyOwnership(ctx, publicKey, ChallengeWithdrawEvidence,
+		ReasonChallengeSubject(evidenceId, reason), challengeTimestamp, signature)
+	if err != nil {
+		return err
+	}
+
+	evidence, err := getEvidence(ctx, evidenceId)
+	if err != nil {
+		return err
+	}
+
+	// Only the submitter may withdraw
+	if !strings.EqualFold(evidence.PublicKeyHash, publicKeyHash) {
+		return fmt.Errorf("evidence %s was not submitted with this key", evidenceId)
+	}
+	if evidence.Status == StatusExported {
+		return fmt.Errorf("evidence %s has been exported for court and can no longer be withdrawn", evidenceId)
+	}
+
+	previousStatus := evidence.Status
+	if _, err := applyTransition(ctx, evidence, TransitionWithdraw); err != nil {
+		return err
+	}
+
+	callerOrg, _ := GetClientOrgID(ctx)
+	timestamp, err := getTxTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+
+	evidence.WithdrawnAt = timestamp
+	evidence.WithdrawalReason = reason
+
+	if err := putEvidence(ctx, evidence); err != nil {
+		return err
+	}
+
+	description := fmt.Sprintf("Evidence withdrawn by submitter (was %s)", previousStatus)
+	if reason != "" {
+		description += fmt.Sprintf(" | Reason: %s", reason)
+	}
+	if err := appendCustodyLog(ctx, evidenceId, ActionWithdraw, description, timestamp); err != nil {
+		return err
+	}
+
+	// Update reputation - withdrawals are tracked apart from rejections
+	if _, err := applyReputationEvent(ctx, publicKeyHash, reputationEvent{
+		EvidenceID: evidenceId,
+		Cause:      ReputationCauseWithdrawn,
+		Detail:     fmt.Sprintf("Evidence withdrawn (was %s)", previousStatus),
+	}, timestamp); err != nil {
+		return fmt.Errorf("failed to update reputation: %v", err)
+	}
+
+	// Tell the organizations working on it to stop
+	message := fmt.Sprintf("Evidence %s was withdrawn by its submitter (status was %s). No further workflow actions are allowed.", evidenceId, previousStatus)
+	for _, orgRole := range []string{OrgRoleVerifier, OrgRoleLegal} {
+		if err := notifyOrgRole(ctx, orgRole, evidenceId, NotifyWithdrawn, message, callerOrg, timestamp); err != nil {
+			return err
+		}
+	}
 
-	return putEvidence(ctx, evidence)
+	return nil
 }
 
-// GetNotifications retrieves all notifications for a given public key hash
-// Whistleblowers can poll this to check if their evidence was rejected/verified
+// GetNotifications retrieves all notifications for the caller's public key
+// Whistleblowers can poll this to check if their evidence was rejected/verified.
+// The caller proves key ownership by signing BuildKeyChallenge(GET_NOTIFICATIONS, hash, "", challengeTimestamp).
 func (c *WhistleblowerContract) GetNotifications(
 	ctx contractapi.TransactionContextInterface,
-	publicKeyHash string,
+	publicKey string,
+	challengeTimestamp int64,
+	signature string,
 ) (*NotificationQueryResult, error) {
 	// Access control: only WhistleblowersOrg can read notifications
 	if err := RequireWhistleblowerOrg(ctx); err != nil {
 		return nil, err
 	}
 
+	// Only the key holder may read this mailbox
+	publicKeyHash, err := verifyKeyOwnership(ctx, publicKey, ChallengeGetNotifications, "", challengeTimestamp, signature)
+	if err != nil {
+		return nil, err
+	}
+
 	// Query notifications by publicKeyHash from PDC
-	queryString := fmt.Sprintf(`{"selector":{"docType":"notification","publicKeyHash":"%s"}}`, publicKeyHash)
-	resultsIterator, err := ctx.GetStub().GetPrivateDataQueryResult(WhistleblowerPrivateCollection, queryString)
+	queryString, err := NewSelector("notification").Equals("publicKeyHash", publicKeyHash).Build()
+	if err != nil {
+		return nil, err
+	}
+	collection, err := orgCollection(ctx, OrgRoleSubmitter)
+	if err != nil {
+		return nil, err
+	}
+	resultsIterator, err := ctx.GetStub().GetPrivateDataQueryResult(collection, queryString)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query notifications: %v", err)
 	}
@@ -321,16 +563,29 @@ func (c *WhistleblowerContract) GetNotifications(
 }
 
 // MarkNotificationRead marks a notification as read
+// The caller signs BuildKeyChallenge(MARK_NOTIFICATION_READ, hash, notificationId, challengeTimestamp).
 func (c *WhistleblowerContract) MarkNotificationRead(
 	ctx contractapi.TransactionContextInterface,
 	notificationId string,
+	publicKey string,
+	challengeTimestamp int64,
+	signature string,
 ) error {
 	// Access control
 	if err := RequireWhistleblowerOrg(ctx); err != nil {
 		return err
 	}
 
-	notificationJSON, err := ctx.GetStub().GetPrivateData(WhistleblowerPrivateCollection, notificationId)
+	publicKeyHash, err := verifyKeyOwnership(ctx, publicKey, ChallengeMarkNotificationRead, notificationId, challengeTimestamp, signature)
+	if err != nil {
+		return err
+	}
+
+	collection, err := orgCollection(ctx, OrgRoleSubmitter)
+	if err != nil {
+		return err
+	}
+	notificationJSON, err := ctx.GetStub().GetPrivateData(collection, notificationId)
 	if err != nil {
 		return fmt.Errorf("failed to get notification: %v", err)
 	}
@@ -343,6 +598,11 @@ func (c *WhistleblowerContract) MarkNotificationRead(
 		return err
 	}
 
+	// Don't reveal whether the notification exists for someone else's key
+	if !strings.EqualFold(notification.PublicKeyHash, publicKeyHash) {
+		return fmt.Errorf("notification %s not found", notificationId)
+	}
+
 	notification.Read = true
 
 	updatedJSON, err := json.Marshal(notification)
@@ -350,39 +610,47 @@ func (c *WhistleblowerContract) MarkNotificationRead(
 		return err
 	}
 
-	return ctx.GetStub().PutPrivateData(WhistleblowerPrivateCollection, notificationId, updatedJSON)
+	return ctx.GetStub().PutPrivateData(collection, notificationId, updatedJSON)
 }
 
-// GetReputation retrieves the reputation score for a public key hash
+// GetReputation retrieves the reputation score for the caller's public key
+// The caller signs BuildKeyChallenge(GET_REPUTATION, hash, "", challengeTimestamp).
 func (c *WhistleblowerContract) GetReputation(
 	ctx contractapi.TransactionContextInterface,
-	publicKeyHash string,
+	publicKey string,
+	challengeTimestamp int64,
+	signature string,
 ) (*Reputation, error) {
 	// Access control: only WhistleblowersOrg
 	if err := RequireWhistleblowerOrg(ctx); err != nil {
 		return nil, err
 	}
 
-	reputationKey := "reputation_" + publicKeyHash
-	reputationJSON, err := ctx.GetStub().GetPrivateData(WhistleblowerPrivateCollection, reputationKey)
+	publicKeyHash, err := verifyKeyOwnership(ctx, publicKey, ChallengeGetReputation, "", challengeTimestamp, signature)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get reputation: %v", err)
-	}
-	if reputationJSON == nil {
-		// No reputation yet - return default
-		return &Reputation{
-			DocType:       "reputation",
-			PublicKeyHash: publicKeyHash,
-			TrustScore:    50,
-		}, nil
+		return nil, err
 	}
 
-	var reputation Reputation
-	if err := json.Unmarshal(reputationJSON, &reputation); err != nil {
+	params, err := getParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// A key without a record yet gets the initial score
+	reputation, err := getReputation(ctx, publicKeyHash, params, 0)
+	if err != nil {
 		return nil, err
 	}
 
-	return &reputation, nil
+	// Report the score as of now (the DECAY model drifts between events)
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reputation.TrustScore, err = currentTrustScore(reputation, params, now)
+	if err != nil {
+		return nil, err
+	}
+	return reputation, nil
 }
 
 // =============================================================================
@@ -394,8 +662,11 @@ type VerifierContract struct {
 	contractapi.Contract
 }
 
-// VerifyIntegrity records the result of hash verification
-// If verification fails, a rejectionComment is REQUIRED and evidence is REJECTED (not forwarded to Legal)
+// VerifyIntegrity records one verifier identity's attestation of the hash check
+// Each identity attests at most once per round. Once VerificationQuorum attestations are in,
+// unanimous pass moves evidence to VERIFIED, unanimous fail to REJECTED (not forwarded to Legal),
+// and any disagreement to DISPUTED, which opens a fresh round.
+// A failed attestation without a rejectionComment gets a default comment.
 func (c *VerifierContract) VerifyIntegrity(
 	ctx contractapi.TransactionContextInterface,
 	evidenceId string,
@@ -404,7 +675,7 @@ func (c *VerifierContract) VerifyIntegrity(
 	rejectionComment string,
 ) error {
 	// Access control: only VerifierOrg can verify
-	if err := RequireVerifierOrg(ctx); err != nil {
+	if err := RequireVerifierOrg(ctx, RoleReviewer, RoleSupervisor); err != nil {
 		return err
 	}
 
@@ -413,9 +684,16 @@ func (c *VerifierContract) VerifyIntegrity(
 		return err
 	}
 
-	// Check current status allows verification
-	if evidence.Status != StatusSubmitted {
-		return fmt.Errorf("evidence status must be %s to verify, current: %s", StatusSubmitted, evidence.Status)
+	// Check current status accepts attestations
+	if _, err := checkTransition(ctx, evidence, TransitionAttest); err != nil {
+		return err
+	}
+
+	if computedHash == "" {
+		return fmt.Errorf("computedHash is required")
+	}
+	if passed && !strings.EqualFold(computedHash, evidence.FileHash) {
+		return fmt.Errorf("computed hash %s does not match stored hash %s; record a failed attestation instead", computedHash, evidence.FileHash)
 	}
 
 	// If verification failed, require a comment
@@ -424,146 +702,256 @@ func (c *VerifierContract) VerifyIntegrity(
 	}
 
 	callerOrg, _ := GetClientOrgID(ctx)
-	timestamp := time.Now().Unix()
+	fingerprint, err := GetClientFingerprint(ctx)
+	if err != nil {
+		return err
+	}
+	timestamp, err := getTxTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+	params, err := getParams(ctx)
+	if err != nil {
+		return err
+	}
 
-	// Update integrity status based on verification result
-	if passed {
-		evidence.IntegrityStatus = IntegrityVerified
-		evidence.Status = StatusVerified
-		
-		// Update reputation - verified
-		if err := updateReputationOnVerify(ctx, evidence.PublicKeyHash, true, timestamp); err != nil {
-			fmt.Printf("Warning: failed to update reputation: %v\n", err)
-		}
-		
-		// Send success notification
-		sendNotification(ctx, evidence.PublicKeyHash, evidenceId, NotifyVerified, 
-			"Your evidence has been successfully verified. It will now proceed to legal review.", callerOrg, timestamp)
-	} else {
-		evidence.IntegrityStatus = IntegrityFailed
-		evidence.Status = StatusRejected // REJECTED - does NOT go to LegalOrg
-		evidence.RejectionComment = rejectionComment
-		
-		// Update reputation - rejected
-		if err := updateReputationOnVerify(ctx, evidence.PublicKeyHash, false, timestamp); err != nil {
-			fmt.Printf("Warning: failed to update reputation: %v\n", err)
+	// During an appeal, verifiers who attested before it may not re-verify
+	if evidence.AppealRound > 0 {
+		if err := checkAppealVerifier(ctx, evidence, fingerprint); err != nil {
+			return err
 		}
-		
-		// Send rejection notification to whistleblower
-		notificationMsg := fmt.Sprintf("Your evidence (ID: %s) was REJECTED during verification. Reason: %s. You may re-upload the evidence with a new ID.", evidenceId, rejectionComment)
-		sendNotification(ctx, evidence.PublicKeyHash, evidenceId, NotifyRejection, notificationMsg, callerOrg, timestamp)
 	}
-	evidence.VerifiedAt = timestamp
 
-	// Build verification description
-	description := fmt.Sprintf("Integrity check: computed=%s, stored=%s, result=%t",
-		computedHash, evidence.FileHash, passed)
-	if !passed {
-		description += fmt.Sprintf(" | Rejection: %s", rejectionComment)
+	// Read the round before writing: a transaction cannot see its own range writes
+	attestations, err := getAttestations(ctx, evidenceId, evidence.VerificationRound)
+	if err != nil {
+		return err
 	}
 
-	// Add custody log
-	evidence.CustodyLog = append(evidence.CustodyLog, CustodyLog{
-		Action:      ActionVerify,
-		ActorOrg:    callerOrg,
-		Timestamp:   timestamp,
-		Description: description,
-	})
-
-	return putEvidence(ctx, evidence)
-}
-
-// updateReputationOnVerify updates reputation after verification
-func updateReputationOnVerify(ctx contractapi.TransactionContextInterface, publicKeyHash string, verified bool, timestamp int64) error {
-	if publicKeyHash == "" {
-		return nil // Legacy evidence without publicKeyHash
+	attestation := &VerificationAttestation{
+		DocType:             "attestation",
+		EvidenceID:          evidenceId,
+		Round:               evidence.VerificationRound,
+		VerifierFingerprint: fingerprint,
+		VerifierOrg:         callerOrg,
+		ComputedHash:        computedHash,
+		Passed:              passed,
+		TxID:                ctx.GetStub().GetTxID(),
+		Timestamp:           timestamp,
 	}
-	
-	reputationKey := "reputation_" + publicKeyHash
-	reputationJSON, err := ctx.GetStub().GetPrivateData(WhistleblowerPrivateCollection, reputationKey)
-	if err != nil || reputationJSON == nil {
-		return nil // No reputation record
+	if !passed {
+		attestation.Comment = rejectionComment
 	}
-
-	var reputation Reputation
-	if err := json.Unmarshal(reputationJSON, &reputation); err != nil {
+	if err := putAttestation(ctx, attestation); err != nil {
 		return err
 	}
+	attestations = append(attestations, attestation)
 
-	if verified {
-		reputation.VerifiedSubmissions++
-		// Increase trust score (max 100)
-		reputation.TrustScore = min(100, reputation.TrustScore + 10)
-	} else {
-		reputation.RejectedSubmissions++
-		// Decrease trust score (min 0)
-		reputation.TrustScore = max(0, reputation.TrustScore - 15)
+	// Build attestation description
+	description := fmt.Sprintf("Attestation %d/%d (round %d) by verifier %s: computed=%s, stored=%s, result=%t",
+		len(attestations), params.VerificationQuorum, attestation.Round, fingerprint, computedHash, evidence.FileHash, passed)
+	if !passed {
+		description += fmt.Sprintf(" | Rejection: %s", rejectionComment)
 	}
-	reputation.LastUpdatedAt = timestamp
-
-	reputationBytes, err := json.Marshal(reputation)
-	if err != nil {
+	if err := appendCustodyLog(ctx, evidenceId, ActionAttest, description, timestamp); err != nil {
 		return err
 	}
 
-	return ctx.GetStub().PutPrivateData(WhistleblowerPrivateCollection, reputationKey, reputationBytes)
-}
-
-// updateReputationOnLegalReview updates reputation after legal review (+3/-3)
-func updateReputationOnLegalReview(ctx contractapi.TransactionContextInterface, publicKeyHash string, verdict string, timestamp int64) error {
-	if publicKeyHash == "" {
+	outcome := decideOutcome(attestations, params.VerificationQuorum)
+	if outcome == OutcomePending {
 		return nil
 	}
 
-	reputationKey := "reputation_" + publicKeyHash
-	reputationJSON, err := ctx.GetStub().GetPrivateData(WhistleblowerPrivateCollection, reputationKey)
+	return c.applyVerificationOutcome(ctx, evidence, outcome, attestations, callerOrg, timestamp)
+}
+
+// applyVerificationOutcome moves evidence to the status decided by a completed attestation round
+func (c *VerifierContract) applyVerificationOutcome(
+	ctx contractapi.TransactionContextInterface,
+	evidence *Evidence,
+	outcome string,
+	attestations []*VerificationAttestation,
+	callerOrg string,
+	timestamp int64,
+) error {
+	evidenceId := evidence.EvidenceID
+	round := evidence.VerificationRound
+	inAppeal := evidence.AppealStatus == AppealPending
+	inReverify := evidence.ReverifyStatus == ReverifyPending
+	params, err := getParams(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to read reputation from PDC: %v", err)
+		return err
 	}
 
-	var reputation Reputation
-	if reputationJSON == nil {
-		// Create default if missing (handles legacy evidence)
-		reputation = Reputation{
-			DocType:             "reputation",
-			PublicKeyHash:       publicKeyHash,
-			TotalSubmissions:    1,
-			VerifiedSubmissions: 1,
-			RejectedSubmissions: 0,
-			ExportedSubmissions: 0,
-			TrustScore:          50,
-			FirstSubmissionAt:   timestamp,
-			LastSubmissionAt:    timestamp,
-			LastUpdatedAt:       timestamp,
+	var description string
+	switch outcome {
+	case OutcomeVerified:
+		if _, err := applyTransition(ctx, evidence, TransitionVerifyPass); err != nil {
+			return err
 		}
-	} else {
-		if err := json.Unmarshal(reputationJSON, &reputation); err != nil {
+		evidence.IntegrityStatus = IntegrityVerified
+		evidence.VerifiedAt = timestamp
+
+		description = fmt.Sprintf("Verification quorum reached in round %d: %d of %d verifiers confirmed the hash",
+			round, len(attestations), len(attestations))
+
+		if inAppeal {
+			// Appeal upheld - undo the rejection penalty before crediting the verification
+			evidence.AppealStatus = AppealUpheld
+			evidence.RejectionComment = ""
+			if _, err := applyReputationEvent(ctx, evidence.PublicKeyHash, reputationEvent{
+				EvidenceID: evidenceId,
+				Cause:      ReputationCauseRejectionReversed,
+				Detail:     "Appeal upheld, rejection penalty reversed",
+				Weight:     -evidence.RejectionReputationDelta,
+				Reversal:   true,
+			}, timestamp); err != nil {
+				return fmt.Errorf("failed to reverse rejection penalty: %v", err)
+			}
+			evidence.RejectionReputationDelta = 0
+			description += " | Appeal upheld, rejection overturned"
+		}
+
+		if inReverify {
+			// Hash re-confirmed - the submission was already credited, legal review starts over
+			evidence.ReverifyStatus = ReverifyConfirmed
+			description += " | Legal-stage hash mismatch not confirmed"
+
+			if err := sendNotification(ctx, evidence.PublicKeyHash, evidenceId, NotifyVerified,
+				fmt.Sprintf("Re-verification of evidence (ID: %s) confirmed its integrity. It will return to legal review.", evidenceId), callerOrg, timestamp); err != nil {
+				return err
+			}
+			if err := notifyOrgRole(ctx, OrgRoleLegal, evidenceId, NotifyVerified,
+				fmt.Sprintf("Re-verification of evidence %s matched the stored hash. It is VERIFIED and ready for a new review.", evidenceId), callerOrg, timestamp); err != nil {
+				return err
+			}
+			break
+		}
+
+		// Update reputation - verified
+		if _, err := applyReputationEvent(ctx, evidence.PublicKeyHash, reputationEvent{
+			EvidenceID: evidenceId,
+			Cause:      ReputationCauseVerified,
+			Detail:     fmt.Sprintf("Verification quorum confirmed the hash in round %d", round),
+			Weight:     params.ReputationVerifyReward,
+		}, timestamp); err != nil {
+			return fmt.Errorf("failed to update reputation: %v", err)
+		}
+
+		// Send success notification
+		if inAppeal {
+			if err := sendNotification(ctx, evidence.PublicKeyHash, evidenceId, NotifyAppealUpheld,
+				fmt.Sprintf("Your appeal for evidence (ID: %s) was UPHELD. The rejection penalty has been reversed and the evidence will now proceed to legal review.", evidenceId), callerOrg, timestamp); err != nil {
+				return err
+			}
+		} else {
+			if err := sendNotification(ctx, evidence.PublicKeyHash, evidenceId, NotifyVerified,
+				"Your evidence has been successfully verified. It will now proceed to legal review.", callerOrg, timestamp); err != nil {
+				return err
+			}
+		}
+
+	case OutcomeRejected:
+		if _, err := applyTransition(ctx, evidence, TransitionVerifyFail); err != nil {
 			return err
 		}
-	}
+		comments := []string{}
+		for _, attestation := range attestations {
+			comments = append(comments, attestation.Comment)
+		}
+		evidence.IntegrityStatus = IntegrityFailed // REJECTED - does NOT go to LegalOrg
+		evidence.RejectionComment = strings.Join(comments, " | ")
+		evidence.VerifiedAt = timestamp
+
+		description = fmt.Sprintf("Verification quorum reached in round %d: %d of %d verifiers found a hash mismatch",
+			round, len(attestations), len(attestations))
+
+		if inAppeal {
+			// Appeal denied - the original penalty stands, no second penalty
+			evidence.AppealStatus = AppealDenied
+			description += " | Appeal denied, rejection stands"
+
+			notificationMsg := fmt.Sprintf("Your appeal for evidence (ID: %s) was DENIED after re-verification. Reason: %s", evidenceId, evidence.RejectionComment)
+			if err := sendNotification(ctx, evidence.PublicKeyHash, evidenceId, NotifyAppealDenied, notificationMsg, callerOrg, timestamp); err != nil {
+				return err
+			}
+			break
+		}
+
+		if inReverify {
+			// Mismatch confirmed - withdraw the earlier verification credit before the rejection penalty
+			evidence.ReverifyStatus = ReverifyFailed
+			description += " | Legal-stage hash mismatch confirmed"
+			if _, err := applyReputationEvent(ctx, evidence.PublicKeyHash, reputationEvent{
+				EvidenceID: evidenceId,
+				Cause:      ReputationCauseVerificationReversed,
+				Detail:     "Legal-stage hash mismatch confirmed, verification credit reversed",
+				Weight:     -params.ReputationVerifyReward,
+				Reversal:   true,
+			}, timestamp); err != nil {
+				return fmt.Errorf("failed to update reputation: %v", err)
+			}
+			if err := notifyOrgRole(ctx, OrgRoleLegal, evidenceId, NotifyHashFailure,
+				fmt.Sprintf("Re-verification of evidence %s confirmed the hash mismatch. It is REJECTED and cannot be exported.", evidenceId), callerOrg, timestamp); err != nil {
+				return err
+			}
+		}
+
+		// Update reputation - rejected (the booked delta is what an upheld appeal reverses)
+		delta, err := applyReputationEvent(ctx, evidence.PublicKeyHash, reputationEvent{
+			EvidenceID: evidenceId,
+			Cause:      ReputationCauseRejected,
+			Detail:     fmt.Sprintf("Verification quorum found a hash mismatch in round %d", round),
+			Weight:     -params.ReputationRejectPenalty,
+		}, timestamp)
+		if err != nil {
+			return fmt.Errorf("failed to update reputation: %v", err)
+		}
+		evidence.RejectionReputationDelta = delta
+
+		// Send rejection notification to whistleblower
+		notificationMsg := fmt.Sprintf("Your evidence (ID: %s) was REJECTED during verification. Reason: %s. You may appeal with AppealRejection or re-upload the evidence with a new ID.", evidenceId, evidence.RejectionComment)
+		if err := sendNotification(ctx, evidence.PublicKeyHash, evidenceId, NotifyRejection, notificationMsg, callerOrg, timestamp); err != nil {
+			return err
+		}
+
+	case OutcomeDisputed:
+		if _, err := applyTransition(ctx, evidence, TransitionVerifyDispute); err != nil {
+			return err
+		}
+		passed := 0
+		for _, attestation := range attestations {
+			if attestation.Passed {
+				passed++
+			}
+		}
+		evidence.IntegrityStatus = IntegrityDisputed
+		evidence.VerificationRound++
+
+		description = fmt.Sprintf("Verification disputed in round %d: %d passed, %d failed; round %d opened",
+			round, passed, len(attestations)-passed, evidence.VerificationRound)
 
-	if verdict == "TRUE" {
-		reputation.TrustScore = min(100, reputation.TrustScore+3)
-	} else if verdict == "FALSE" {
-		reputation.TrustScore = max(0, reputation.TrustScore-3)
+	default:
+		return fmt.Errorf("unknown verification outcome %s", outcome)
 	}
 
-	reputation.LastUpdatedAt = timestamp
-	reputationBytes, err := json.Marshal(reputation)
-	if err != nil {
+	if err := putEvidence(ctx, evidence); err != nil {
 		return err
 	}
 
-	return ctx.GetStub().PutPrivateData(WhistleblowerPrivateCollection, reputationKey, reputationBytes)
+	// Add custody log
+	return appendCustodyLog(ctx, evidenceId, ActionVerify, description, timestamp)
 }
 
 // sendNotification creates a notification for the whistleblower
+// One transaction may notify several times about the same evidence (quorum outcome, appeal,
+// re-verification), so IDs are notif_<evidenceId>_<txId>_<sequence within the transaction>.
 func sendNotification(ctx contractapi.TransactionContextInterface, publicKeyHash string, evidenceId string, messageType string, message string, fromOrg string, timestamp int64) error {
 	if publicKeyHash == "" {
 		return nil // Legacy evidence
 	}
 
-	notificationId := fmt.Sprintf("notif_%s_%d", evidenceId, timestamp)
+	notificationId := fmt.Sprintf("notif_%s_%s_%d", evidenceId, ctx.GetStub().GetTxID(), nextNotificationSequence(ctx))
 	notification := Notification{
 		DocType:        "notification",
 		NotificationID: notificationId,
@@ -581,7 +969,59 @@ func sendNotification(ctx contractapi.TransactionContextInterface, publicKeyHash
 		return err
 	}
 
-	return ctx.GetStub().PutPrivateData(WhistleblowerPrivateCollection, notificationId, notificationJSON)
+	collection, err := orgCollection(ctx, OrgRoleSubmitter)
+	if err != nil {
+		return err
+	}
+	if err := writePrivateData(ctx, collection, notificationId, notificationJSON); err != nil {
+		return err
+	}
+
+	// The event names neither the key nor the message
+	return emitEvent(ctx, &events.Event{
+		Type:             events.NotificationSent,
+		EvidenceID:       evidenceId,
+		NotificationType: messageType,
+	})
+}
+
+// sendOrgNotification records a notification for an organization on the public ledger
+// Verifier and Legal collections only accept writes from their own members, so
+// notifications raised by another org are stored publicly under org_notification~orgMSP~timestamp~evidenceId~txId.
+func sendOrgNotification(ctx contractapi.TransactionContextInterface, orgMSP string, evidenceId string, messageType string, message string, fromOrg string, timestamp int64) error {
+	txID := ctx.GetStub().GetTxID()
+	key, err := ctx.GetStub().CreateCompositeKey(orgNotificationObjectType,
+		[]string{orgMSP, fmt.Sprintf(orgNotificationTimeFormat, timestamp), evidenceId, txID})
+	if err != nil {
+		return fmt.Errorf("failed to create org notification key: %v", err)
+	}
+
+	notification := OrgNotification{
+		DocType:        "org_notification",
+		NotificationID: key,
+		OrgMSP:         orgMSP,
+		EvidenceID:     evidenceId,
+		MessageType:    messageType,
+		Message:        message,
+		FromOrg:        fromOrg,
+		TxID:           txID,
+		Timestamp:      timestamp,
+	}
+
+	notificationJSON, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	if err := ctx.GetStub().PutState(key, notificationJSON); err != nil {
+		return err
+	}
+	return emitEvent(ctx, &events.Event{
+		Type:             events.NotificationSent,
+		EvidenceID:       evidenceId,
+		NotificationType: messageType,
+		RecipientOrg:     orgMSP,
+	})
 }
 
 // Helper functions for min/max (Go 1.17 compatible)
@@ -608,21 +1048,24 @@ func (c *VerifierContract) AddVerificationNote(
 	hashComparison string,
 ) error {
 	// Access control
-	if err := RequireVerifierOrg(ctx); err != nil {
+	if err := RequireVerifierOrg(ctx, RoleReviewer, RoleSupervisor); err != nil {
 		return err
 	}
 
-	// Verify evidence exists
-	exists, err := evidenceExists(ctx, evidenceId)
+	// Verify evidence exists and is still in the workflow
+	evidence, err := getEvidence(ctx, evidenceId)
 	if err != nil {
 		return err
 	}
-	if !exists {
-		return fmt.Errorf("evidence %s does not exist", evidenceId)
+	if err := requireOpenEvidence(evidence); err != nil {
+		return err
 	}
 
 	callerOrg, _ := GetClientOrgID(ctx)
-	timestamp := time.Now().Unix()
+	timestamp, err := getTxTimestamp(ctx)
+	if err != nil {
+		return err
+	}
 
 	// Create verification note
 	note := VerificationNote{
@@ -641,22 +1084,21 @@ func (c *VerifierContract) AddVerificationNote(
 	}
 
 	// Store in private data collection
+	collection, err := orgCollection(ctx, OrgRoleVerifier)
+	if err != nil {
+		return err
+	}
 	noteKey := fmt.Sprintf("note_%s_%s", evidenceId, noteId)
-	err = ctx.GetStub().PutPrivateData(VerifierPrivateCollection, noteKey, noteJSON)
+	err = ctx.GetStub().PutPrivateData(collection, noteKey, noteJSON)
 	if err != nil {
 		return fmt.Errorf("failed to store verification note in PDC: %v", err)
 	}
 
-	// Update custody log on public ledger
-	evidence, _ := getEvidence(ctx, evidenceId)
-	evidence.CustodyLog = append(evidence.CustodyLog, CustodyLog{
-		Action:      ActionAddNote,
-		ActorOrg:    callerOrg,
-		Timestamp:   timestamp,
-		Description: "Verification note added (private)",
-	})
-
-	return putEvidence(ctx, evidence)
+	// Update custody log on public ledger (the evidence document itself is not rewritten)
+	if err := appendCustodyLog(ctx, evidenceId, ActionAddNote, "Verification note added (private)", timestamp); err != nil {
+		return err
+	}
+	return emitStatusEvent(ctx, events.NoteAdded, evidence)
 }
 
 // GetVerificationNotes retrieves private verification notes (PDC - VerifierOrg only)
@@ -665,13 +1107,20 @@ func (c *VerifierContract) GetVerificationNotes(
 	evidenceId string,
 ) ([]*VerificationNote, error) {
 	// Access control
-	if err := RequireVerifierOrg(ctx); err != nil {
+	if err := RequireVerifierOrg(ctx, RoleReviewer, RoleSupervisor, RoleAuditor); err != nil {
 		return nil, err
 	}
 
 	// Query private data collection
-	queryString := fmt.Sprintf(`{"selector":{"docType":"verification_note","evidenceId":"%s"}}`, evidenceId)
-	resultsIterator, err := ctx.GetStub().GetPrivateDataQueryResult(VerifierPrivateCollection, queryString)
+	queryString, err := NewSelector("verification_note").Equals("evidenceId", evidenceId).Build()
+	if err != nil {
+		return nil, err
+	}
+	collection, err := orgCollection(ctx, OrgRoleVerifier)
+	if err != nil {
+		return nil, err
+	}
+	resultsIterator, err := ctx.GetStub().GetPrivateDataQueryResult(collection, queryString)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query verification notes: %v", err)
 	}
@@ -704,6 +1153,8 @@ type LegalContract struct {
 }
 
 // ReviewEvidence marks evidence as under legal review or reviewed
+// Completing a review requires a verdict, plus a justification and salt in the transient map
+// (see getTransientJustification); all are ignored when starting one.
 func (c *LegalContract) ReviewEvidence(
 	ctx contractapi.TransactionContextInterface,
 	evidenceId string,
@@ -711,47 +1162,92 @@ func (c *LegalContract) ReviewEvidence(
 	verdict string,
 ) error {
 	// Access control
-	if err := RequireLegalOrg(ctx); err != nil {
+	if err := RequireLegalOrg(ctx, RoleReviewer, RoleSupervisor); err != nil {
 		return err
 	}
 
+	var justification string
+	var salt []byte
+	if reviewComplete {
+		if err := validateVerdict(verdict); err != nil {
+			return err
+		}
+		var err error
+		justification, salt, err = getTransientJustification(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
 	evidence, err := getEvidence(ctx, evidenceId)
 	if err != nil {
 		return err
 	}
 
 	// Verify status allows review
-	if evidence.Status != StatusVerified && evidence.Status != StatusUnderReview {
-		return fmt.Errorf("evidence must be VERIFIED or UNDER_REVIEW to review, current: %s", evidence.Status)
+	action := TransitionStartReview
+	if reviewComplete {
+		action = TransitionCompleteReview
+	}
+	transition, err := applyTransition(ctx, evidence, action)
+	if err != nil {
+		return err
 	}
 
-	callerOrg, _ := GetClientOrgID(ctx)
-	timestamp := time.Now().Unix()
+	timestamp, err := getTxTimestamp(ctx)
+	if err != nil {
+		return err
+	}
 
 	var description string
 	if reviewComplete {
-		evidence.Status = StatusReviewed
-		evidence.ReviewedAt = timestamp
-		description = fmt.Sprintf("Legal review completed. Verdict: %s", verdict)
+		params, err := getParams(ctx)
+		if err != nil {
+			return err
+		}
+		justificationHash, err := putReviewJustification(ctx, evidenceId, verdict, justification, salt, timestamp)
+		if err != nil {
+			return err
+		}
 
 		// Update reputation based on verdict
-		if err := updateReputationOnLegalReview(ctx, evidence.PublicKeyHash, verdict, timestamp); err != nil {
+		delta, err := applyReputationEvent(ctx, evidence.PublicKeyHash, reputationEvent{
+			EvidenceID: evidenceId,
+			Cause:      ReputationCauseLegalVerdict,
+			Detail:     fmt.Sprintf("Legal verdict %s", verdict),
+			Weight:     verdictReputationEffect(params, verdict),
+		}, timestamp)
+		if err != nil {
 			return fmt.Errorf("failed to update reputation: %v", err)
 		}
+
+		evidence.ReviewedAt = timestamp
+		evidence.Verdict = verdict
+		evidence.VerdictReputationDelta = delta
+		evidence.JustificationHash = justificationHash
+		description = fmt.Sprintf("Legal review completed. Verdict: %s (justification %s)", verdict, justificationHash)
 	} else {
-		evidence.Status = StatusUnderReview
 		description = "Legal review started"
 	}
 
+	if err := putEvidence(ctx, evidence); err != nil {
+		return err
+	}
+
 	// Add custody log
-	evidence.CustodyLog = append(evidence.CustodyLog, CustodyLog{
-		Action:      ActionReview,
-		ActorOrg:    callerOrg,
-		Timestamp:   timestamp,
-		Description: description,
+	if err := appendCustodyLog(ctx, evidenceId, ActionReview, description, timestamp); err != nil {
+		return err
+	}
+	if !reviewComplete {
+		return nil // START_REVIEW emitted its event in applyTransition
+	}
+	return emitEvent(ctx, &events.Event{
+		Type:       events.ReviewCompleted,
+		EvidenceID: evidenceId,
+		OldStatus:  transition.From,
+		NewStatus:  transition.To,
+		Verdict:    evidence.Verdict,
 	})
-
-	return putEvidence(ctx, evidence)
 }
 
 // AddLegalComment adds private legal assessment (PDC - LegalOrg only)
@@ -764,21 +1260,24 @@ func (c *LegalContract) AddLegalComment(
 	recommendation string,
 ) error {
 	// Access control
-	if err := RequireLegalOrg(ctx); err != nil {
+	if err := RequireLegalOrg(ctx, RoleReviewer, RoleSupervisor, RoleExporter); err != nil {
 		return err
 	}
 
-	// Verify evidence exists
-	exists, err := evidenceExists(ctx, evidenceId)
+	// Verify evidence exists and is still in the workflow
+	evidence, err := getEvidence(ctx, evidenceId)
 	if err != nil {
 		return err
 	}
-	if !exists {
-		return fmt.Errorf("evidence %s does not exist", evidenceId)
+	if err := requireOpenEvidence(evidence); err != nil {
+		return err
 	}
 
 	callerOrg, _ := GetClientOrgID(ctx)
-	timestamp := time.Now().Unix()
+	timestamp, err := getTxTimestamp(ctx)
+	if err != nil {
+		return err
+	}
 
 	// Create legal comment
 	comment := LegalComment{
@@ -798,20 +1297,23 @@ func (c *LegalContract) AddLegalComment(
 	}
 
 	// Store in private data collection
+	collection, err := orgCollection(ctx, OrgRoleLegal)
+	if err != nil {
+		return err
+	}
 	commentKey := fmt.Sprintf("comment_%s_%s", evidenceId, commentId)
-	err = ctx.GetStub().PutPrivateData(LegalPrivateCollection, commentKey, commentJSON)
+	err = ctx.GetStub().PutPrivateData(collection, commentKey, commentJSON)
 	if err != nil {
 		return fmt.Errorf("failed to store legal comment in PDC: %v", err)
 	}
 
-	// Update custody log on public ledger
-	evidence, _ := getEvidence(ctx, evidenceId)
-	evidence.CustodyLog = append(evidence.CustodyLog, CustodyLog{
-		Action:      ActionAddComment,
-		ActorOrg:    callerOrg,
-		Timestamp:   timestamp,
-		Description: "Legal comment added (private)",
-	})
+	// Update custody log on public ledger (the evidence document itself is not rewritten)
+	if err := appendCustodyLog(ctx, evidenceId, ActionAddComment, "Legal comment added (private)", timestamp); err != nil {
+		return err
+	}
+	if err := emitStatusEvent(ctx, events.CommentAdded, evidence); err != nil {
+		return err
+	}
 
 	// Notify whistleblower
 	msgSnippet := content
@@ -819,9 +1321,11 @@ func (c *LegalContract) AddLegalComment(
 		msgSnippet = msgSnippet[:77] + "..."
 	}
 	notificationMsg := fmt.Sprintf("Legal comment added (%s): %s", recommendation, msgSnippet)
-	sendNotification(ctx, evidence.PublicKeyHash, evidenceId, "LEGAL_COMMENT", notificationMsg, callerOrg, timestamp)
+	if err := sendNotification(ctx, evidence.PublicKeyHash, evidenceId, "LEGAL_COMMENT", notificationMsg, callerOrg, timestamp); err != nil {
+		return err
+	}
 
-	return putEvidence(ctx, evidence)
+	return nil
 }
 
 // GetLegalComments retrieves private legal comments (PDC - LegalOrg only)
@@ -830,13 +1334,20 @@ func (c *LegalContract) GetLegalComments(
 	evidenceId string,
 ) ([]*LegalComment, error) {
 	// Access control
-	if err := RequireLegalOrg(ctx); err != nil {
+	if err := RequireLegalOrg(ctx, RoleReviewer, RoleSupervisor, RoleExporter, RoleAuditor); err != nil {
 		return nil, err
 	}
 
 	// Query private data collection
-	queryString := fmt.Sprintf(`{"selector":{"docType":"legal_comment","evidenceId":"%s"}}`, evidenceId)
-	resultsIterator, err := ctx.GetStub().GetPrivateDataQueryResult(LegalPrivateCollection, queryString)
+	queryString, err := NewSelector("legal_comment").Equals("evidenceId", evidenceId).Build()
+	if err != nil {
+		return nil, err
+	}
+	collection, err := orgCollection(ctx, OrgRoleLegal)
+	if err != nil {
+		return nil, err
+	}
+	resultsIterator, err := ctx.GetStub().GetPrivateDataQueryResult(collection, queryString)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query legal comments: %v", err)
 	}
@@ -859,67 +1370,6 @@ func (c *LegalContract) GetLegalComments(
 	return comments, nil
 }
 
-// ExportEvidence generates a court-ready export record
-func (c *LegalContract) ExportEvidence(
-	ctx contractapi.TransactionContextInterface,
-	evidenceId string,
-) (*ExportRecord, error) {
-	// Access control
-	if err := RequireLegalOrg(ctx); err != nil {
-		return nil, err
-	}
-
-	evidence, err := getEvidence(ctx, evidenceId)
-	if err != nil {
-		return nil, err
-	}
-
-	// Verify status allows export
-	if evidence.Status != StatusReviewed && evidence.Status != StatusExported {
-		return nil, fmt.Errorf("evidence must be REVIEWED to export, current: %s", evidence.Status)
-	}
-
-	callerOrg, _ := GetClientOrgID(ctx)
-	timestamp := time.Now().Unix()
-
-	// Create export record
-	exportRecord := ExportRecord{
-		EvidenceID:      evidence.EvidenceID,
-		IPFSCID:         evidence.IPFSCID,
-		FileHash:        evidence.FileHash,
-		FileType:        evidence.FileType,
-		Category:        evidence.Category,
-		SubmittedAt:     evidence.SubmittedAt,
-		VerifiedAt:      evidence.VerifiedAt,
-		ReviewedAt:      evidence.ReviewedAt,
-		ExportedAt:      timestamp,
-		PolygonTxHash:   evidence.PolygonTxHash,
-		IntegrityStatus: evidence.IntegrityStatus,
-		CustodyLog:      evidence.CustodyLog,
-	}
-
-	// Generate hash of export record for integrity
-	exportJSON, _ := json.Marshal(exportRecord)
-	hash := sha256.Sum256(exportJSON)
-	exportRecord.ExportHash = hex.EncodeToString(hash[:])
-
-	// Update evidence status
-	evidence.Status = StatusExported
-	evidence.ExportedAt = timestamp
-	evidence.CustodyLog = append(evidence.CustodyLog, CustodyLog{
-		Action:      ActionExport,
-		ActorOrg:    callerOrg,
-		Timestamp:   timestamp,
-		Description: fmt.Sprintf("Evidence exported for court proceedings. Export hash: %s", exportRecord.ExportHash),
-	})
-
-	if err := putEvidence(ctx, evidence); err != nil {
-		return nil, err
-	}
-
-	return &exportRecord, nil
-}
-
 // QueryEvidenceByDateRange retrieves evidence within a date range (LegalOrg only)
 func (c *LegalContract) QueryEvidenceByDateRange(
 	ctx contractapi.TransactionContextInterface,
@@ -929,20 +1379,17 @@ func (c *LegalContract) QueryEvidenceByDateRange(
 	bookmark string,
 ) (*EvidenceQueryResult, error) {
 	// Access control: ONLY LegalOrg can search by date range
-	if err := RequireLegalOrg(ctx); err != nil {
+	if err := RequireLegalOrg(ctx, RoleReviewer, RoleSupervisor, RoleExporter, RoleAuditor); err != nil {
 		return nil, fmt.Errorf("date range search is restricted to LegalOrg for manual authentication: %v", err)
 	}
 
-	queryString := fmt.Sprintf(`{
-		"selector": {
-			"docType": "evidence",
-			"submittedAt": {
-				"$gte": %d,
-				"$lte": %d
-			}
-		},
-		"sort": [{"submittedAt": "desc"}]
-	}`, startTimestamp, endTimestamp)
+	queryString, err := NewSelector("evidence").
+		Between("submittedAt", startTimestamp, endTimestamp).
+		SortDesc("submittedAt").
+		Build()
+	if err != nil {
+		return nil, err
+	}
 
 	return getQueryResultWithPagination(ctx, queryString, pageSize, bookmark)
 }
@@ -966,7 +1413,180 @@ func (c *QueryContract) GetEvidence(
 		return nil, err
 	}
 
-	return getEvidence(ctx, evidenceId)
+	return getEvidenceWithCustody(ctx, evidenceId)
+}
+
+// GetCustodyLog pages through the custody entries of an evidence item in sequence order
+func (c *QueryContract) GetCustodyLog(
+	ctx contractapi.TransactionContextInterface,
+	evidenceId string,
+	pageSize int32,
+	bookmark string,
+) (*CustodyLogQueryResult, error) {
+	if err := RequireAnyOrg(ctx); err != nil {
+		return nil, err
+	}
+
+	resultsIterator, responseMetadata, err := ctx.GetStub().GetStateByPartialCompositeKeyWithPagination(
+		custodyEntryObjectType, []string{evidenceId}, pageSize, bookmark)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read custody log for %s: %v", evidenceId, err)
+	}
+	defer resultsIterator.Close()
+
+	entries := []CustodyLog{}
+	for resultsIterator.HasNext() {
+		queryResult, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		var entry CustodyLog
+		if err := json.Unmarshal(queryResult.Value, &entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	// Evidence not migrated yet still carries its log inline (returned as a single page)
+	if len(entries) == 0 && bookmark == "" {
+		if entries, err = getLegacyCustodyLog(ctx, evidenceId); err != nil {
+			return nil, err
+		}
+		if entries == nil {
+			entries = []CustodyLog{}
+		}
+	}
+
+	return &CustodyLogQueryResult{
+		EvidenceID:          evidenceId,
+		Entries:             entries,
+		FetchedRecordsCount: len(entries),
+		Bookmark:            responseMetadata.Bookmark,
+	}, nil
+}
+
+// VerifyCustodyChain recomputes the custody hash chain and cross-checks it against ledger history.
+// Returns a pass/fail report naming the first divergent entry.
+func (c *QueryContract) VerifyCustodyChain(
+	ctx contractapi.TransactionContextInterface,
+	evidenceId string,
+) (*CustodyChainReport, error) {
+	if err := RequireAnyOrg(ctx); err != nil {
+		return nil, err
+	}
+
+	return verifyCustodyChain(ctx, evidenceId)
+}
+
+// GetEvidenceLineage returns every version of an evidence item, oldest first
+// Works from any version in the chain.
+func (c *QueryContract) GetEvidenceLineage(
+	ctx contractapi.TransactionContextInterface,
+	evidenceId string,
+) (*EvidenceLineage, error) {
+	if err := RequireAnyOrg(ctx); err != nil {
+		return nil, err
+	}
+
+	return loadEvidenceLineage(ctx, evidenceId)
+}
+
+// GetAllowedTransitions lists the lifecycle actions the calling org can take next
+func (c *QueryContract) GetAllowedTransitions(
+	ctx contractapi.TransactionContextInterface,
+	evidenceId string,
+) (*AllowedTransitionsResult, error) {
+	if err := RequireAnyOrg(ctx); err != nil {
+		return nil, err
+	}
+
+	evidence, err := getEvidence(ctx, evidenceId)
+	if err != nil {
+		return nil, err
+	}
+
+	callerMSP, callerRoles, err := getCallerOrgRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AllowedTransitionsResult{
+		EvidenceID:  evidenceId,
+		Status:      evidence.Status,
+		CallerMSP:   callerMSP,
+		Transitions: findTransitions(evidence.Status, "", callerRoles),
+	}, nil
+}
+
+// GetAttestations lists every verifier attestation for an evidence item, oldest round first
+func (c *QueryContract) GetAttestations(
+	ctx contractapi.TransactionContextInterface,
+	evidenceId string,
+) ([]*VerificationAttestation, error) {
+	if err := RequireAnyOrg(ctx); err != nil {
+		return nil, err
+	}
+
+	if _, err := getEvidence(ctx, evidenceId); err != nil {
+		return nil, err
+	}
+
+	return getAttestations(ctx, evidenceId, -1)
+}
+
+// GetParams returns the active workflow parameters
+func (c *QueryContract) GetParams(
+	ctx contractapi.TransactionContextInterface,
+) (*ChainProofParams, error) {
+	if err := RequireAnyOrg(ctx); err != nil {
+		return nil, err
+	}
+
+	return getParams(ctx)
+}
+
+// GetOrgNotifications pages through notifications addressed to the caller's organization, oldest first
+func (c *QueryContract) GetOrgNotifications(
+	ctx contractapi.TransactionContextInterface,
+	pageSize int32,
+	bookmark string,
+) (*OrgNotificationQueryResult, error) {
+	if err := RequireAnyOrg(ctx); err != nil {
+		return nil, err
+	}
+
+	callerMSP, err := GetClientOrgID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resultsIterator, responseMetadata, err := ctx.GetStub().GetStateByPartialCompositeKeyWithPagination(
+		orgNotificationObjectType, []string{callerMSP}, pageSize, bookmark)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query org notifications: %v", err)
+	}
+	defer resultsIterator.Close()
+
+	notifications := []*OrgNotification{}
+	for resultsIterator.HasNext() {
+		queryResult, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		var notification OrgNotification
+		if err := json.Unmarshal(queryResult.Value, &notification); err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, &notification)
+	}
+
+	return &OrgNotificationQueryResult{
+		Notifications:       notifications,
+		FetchedRecordsCount: len(notifications),
+		Bookmark:            responseMetadata.Bookmark,
+	}, nil
 }
 
 // GetAllEvidence retrieves all evidence with pagination
@@ -979,7 +1599,10 @@ func (c *QueryContract) GetAllEvidence(
 		return nil, err
 	}
 
-	queryString := `{"selector":{"docType":"evidence"}}`
+	queryString, err := NewSelector("evidence").Build()
+	if err != nil {
+		return nil, err
+	}
 	return getQueryResultWithPagination(ctx, queryString, pageSize, bookmark)
 }
 
@@ -994,7 +1617,10 @@ func (c *QueryContract) QueryEvidenceByStatus(
 		return nil, err
 	}
 
-	queryString := fmt.Sprintf(`{"selector":{"docType":"evidence","status":"%s"}}`, status)
+	queryString, err := NewSelector("evidence").Equals("status", status).Build()
+	if err != nil {
+		return nil, err
+	}
 	return getQueryResultWithPagination(ctx, queryString, pageSize, bookmark)
 }
 
@@ -1009,7 +1635,10 @@ func (c *QueryContract) QueryEvidenceByCategory(
 		return nil, err
 	}
 
-	queryString := fmt.Sprintf(`{"selector":{"docType":"evidence","category":"%s"}}`, category)
+	queryString, err := NewSelector("evidence").Equals("category", category).Build()
+	if err != nil {
+		return nil, err
+	}
 	return getQueryResultWithPagination(ctx, queryString, pageSize, bookmark)
 }
 
@@ -1022,7 +1651,10 @@ func (c *QueryContract) QueryEvidenceByBulkSubmission(
 		return nil, err
 	}
 
-	queryString := fmt.Sprintf(`{"selector":{"docType":"evidence","bulkSubmissionId":"%s"}}`, bulkSubmissionId)
+	queryString, err := NewSelector("evidence").Equals("bulkSubmissionId", bulkSubmissionId).Build()
+	if err != nil {
+		return nil, err
+	}
 	return getQueryResultWithPagination(ctx, queryString, 100, "")
 }
 
@@ -1034,7 +1666,10 @@ func (c *QueryContract) GetEvidenceCount(
 		return 0, err
 	}
 
-	queryString := `{"selector":{"docType":"evidence"}}`
+	queryString, err := NewSelector("evidence").Build()
+	if err != nil {
+		return 0, err
+	}
 	resultsIterator, err := ctx.GetStub().GetQueryResult(queryString)
 	if err != nil {
 		return 0, err
@@ -1062,34 +1697,9 @@ func (c *QueryContract) GetEvidenceHistory(
 		return nil, err
 	}
 
-	resultsIterator, err := ctx.GetStub().GetHistoryForKey(evidenceId)
+	history, err := getEvidenceVersions(ctx, evidenceId)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get history for %s: %v", evidenceId, err)
-	}
-	defer resultsIterator.Close()
-
-	var history []*HistoryEntry
-	for resultsIterator.HasNext() {
-		response, err := resultsIterator.Next()
-		if err != nil {
-			return nil, err
-		}
-
-		entry := &HistoryEntry{
-			TxId:      response.TxId,
-			Timestamp: response.Timestamp.Seconds,
-			IsDelete:  response.IsDelete,
-		}
-
-		if !response.IsDelete {
-			var evidence Evidence
-			if err := json.Unmarshal(response.Value, &evidence); err != nil {
-				return nil, err
-			}
-			entry.Value = &evidence
-		}
-
-		history = append(history, entry)
+		return nil, err
 	}
 
 	return &EvidenceHistory{
@@ -1107,7 +1717,7 @@ func evidenceExists(
 	ctx contractapi.TransactionContextInterface,
 	evidenceId string,
 ) (bool, error) {
-	evidenceJSON, err := ctx.GetStub().GetState(evidenceId)
+	evidenceJSON, err := readState(ctx, evidenceId)
 	if err != nil {
 		return false, fmt.Errorf("failed to read from world state: %v", err)
 	}
@@ -1119,7 +1729,7 @@ func getEvidence(
 	ctx contractapi.TransactionContextInterface,
 	evidenceId string,
 ) (*Evidence, error) {
-	evidenceJSON, err := ctx.GetStub().GetState(evidenceId)
+	evidenceJSON, err := readState(ctx, evidenceId)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read evidence %s: %v", evidenceId, err)
 	}
@@ -1135,16 +1745,73 @@ func getEvidence(
 	return &evidence, nil
 }
 
+// getEvidenceVersions returns every committed version of an evidence record, newest first (as Fabric returns them)
+func getEvidenceVersions(
+	ctx contractapi.TransactionContextInterface,
+	evidenceId string,
+) ([]*HistoryEntry, error) {
+	resultsIterator, err := ctx.GetStub().GetHistoryForKey(evidenceId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get history for %s: %v", evidenceId, err)
+	}
+	defer resultsIterator.Close()
+
+	var history []*HistoryEntry
+	for resultsIterator.HasNext() {
+		response, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		entry := &HistoryEntry{
+			TxId:      response.TxId,
+			Timestamp: response.Timestamp.Seconds,
+			IsDelete:  response.IsDelete,
+		}
+
+		if !response.IsDelete {
+			var evidence Evidence
+			if err := json.Unmarshal(response.Value, &evidence); err != nil {
+				return nil, err
+			}
+			entry.Value = &evidence
+		}
+
+		history = append(history, entry)
+	}
+
+	return history, nil
+}
+
+// getTxTimestamp returns the transaction proposal timestamp in Unix seconds.
+// All endorsing peers see the same value, so write-sets stay deterministic.
+func getTxTimestamp(ctx contractapi.TransactionContextInterface) (int64, error) {
+	txTimestamp, err := ctx.GetStub().GetTxTimestamp()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get transaction timestamp: %v", err)
+	}
+	return txTimestamp.GetSeconds(), nil
+}
+
 // putEvidence internal helper to store evidence
+// The custody log lives in its own records, so it is never written into the evidence document;
+// a legacy embedded log is moved into the chain before the document drops it.
 func putEvidence(
 	ctx contractapi.TransactionContextInterface,
 	evidence *Evidence,
 ) error {
-	evidenceJSON, err := json.Marshal(evidence)
+	if _, err := migrateLegacyCustodyLog(ctx, evidence.EvidenceID); err != nil {
+		return err
+	}
+
+	stored := *evidence
+	stored.CustodyLog = nil
+
+	evidenceJSON, err := json.Marshal(stored)
 	if err != nil {
 		return fmt.Errorf("failed to marshal evidence: %v", err)
 	}
-	return ctx.GetStub().PutState(evidence.EvidenceID, evidenceJSON)
+	return writeState(ctx, evidence.EvidenceID, evidenceJSON)
 }
 
 // getQueryResultWithPagination helper for paginated queries
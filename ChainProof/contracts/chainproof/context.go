@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/chainproof/chaincode/events"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// =============================================================================
+// ChainProof - Transaction Context
+// =============================================================================
+// Fabric does not let a transaction read its own writes: GetState returns the
+// committed value even after PutState in the same transaction. Records that a
+// single transaction may update more than once (custody chain heads, evidence,
+// reputation) go through readState/writeState/deleteState, which serve pending
+// writes and deletions from a per-transaction cache held on ChainProofContext.
+// The context also collects the transaction's chaincode events (see
+// chaincode_events.go) and numbers the notifications and reputation changes it
+// records.
+// =============================================================================
+
+// ChainProofContext is the transaction context used by every ChainProof contract
+type ChainProofContext struct {
+	contractapi.TransactionContext
+	pendingWrites     map[string][]byte // collection~key -> value written (nil if deleted) earlier in this transaction
+	emitted           []*events.Event   // Chaincode events recorded so far in this transaction
+	notifications     int               // Whistleblower notifications sent so far in this transaction
+	reputationChanges int               // Reputation history records written so far in this transaction
+}
+
+// pendingWriteKey namespaces cached writes by collection ("" is public state)
+func pendingWriteKey(collection string, key string) string {
+	return collection + "\x00" + key
+}
+
+// cached returns a value written earlier in this transaction
+func (c *ChainProofContext) cached(collection string, key string) ([]byte, bool) {
+	value, ok := c.pendingWrites[pendingWriteKey(collection, key)]
+	return value, ok
+}
+
+// cache records a value written in this transaction
+func (c *ChainProofContext) cache(collection string, key string, value []byte) {
+	if c.pendingWrites == nil {
+		c.pendingWrites = map[string][]byte{}
+	}
+	c.pendingWrites[pendingWriteKey(collection, key)] = value
+}
+
+// readState reads public state, seeing earlier writes from this transaction
+func readState(ctx contractapi.TransactionContextInterface, key string) ([]byte, error) {
+	if cpCtx, ok := ctx.(*ChainProofContext); ok {
+		if value, found := cpCtx.cached("", key); found {
+			return value, nil
+		}
+	}
+	return ctx.GetStub().GetState(key)
+}
+
+// writeState writes public state and remembers the value for later reads in this transaction
+func writeState(ctx contractapi.TransactionContextInterface, key string, value []byte) error {
+	if err := ctx.GetStub().PutState(key, value); err != nil {
+		return fmt.Errorf("failed to write %s: %v", key, err)
+	}
+	if cpCtx, ok := ctx.(*ChainProofContext); ok {
+		cpCtx.cache("", key, value)
+	}
+	return nil
+}
+
+// deleteState deletes public state and remembers the deletion for later reads in this transaction
+func deleteState(ctx contractapi.TransactionContextInterface, key string) error {
+	if err := ctx.GetStub().DelState(key); err != nil {
+		return fmt.Errorf("failed to delete %s: %v", key, err)
+	}
+	if cpCtx, ok := ctx.(*ChainProofContext); ok {
+		cpCtx.cache("", key, nil)
+	}
+	return nil
+}
+
+// readPrivateData reads a private collection, seeing earlier writes from this transaction
+func readPrivateData(ctx contractapi.TransactionContextInterface, collection string, key string) ([]byte, error) {
+	if cpCtx, ok := ctx.(*ChainProofContext); ok {
+		if value, found := cpCtx.cached(collection, key); found {
+			return value, nil
+		}
+	}
+	return ctx.GetStub().GetPrivateData(collection, key)
+}
+
+// writePrivateData writes a private collection and remembers the value for later reads in this transaction
+func writePrivateData(ctx contractapi.TransactionContextInterface, collection string, key string, value []byte) error {
+	if err := ctx.GetStub().PutPrivateData(collection, key, value); err != nil {
+		return fmt.Errorf("failed to write %s to %s: %v", key, collection, err)
+	}
+	if cpCtx, ok := ctx.(*ChainProofContext); ok {
+		cpCtx.cache(collection, key, value)
+	}
+	return nil
+}
+
+// nextNotificationSequence numbers the notifications sent in this transaction, starting at 0
+func nextNotificationSequence(ctx contractapi.TransactionContextInterface) int {
+	cpCtx, ok := ctx.(*ChainProofContext)
+	if !ok {
+		return 0
+	}
+	sequence := cpCtx.notifications
+	cpCtx.notifications++
+	return sequence
+}
+
+// nextReputationChangeSequence numbers the reputation history records written in this transaction, starting at 0
+func nextReputationChangeSequence(ctx contractapi.TransactionContextInterface) int {
+	cpCtx, ok := ctx.(*ChainProofContext)
+	if !ok {
+		return 0
+	}
+	sequence := cpCtx.reputationChanges
+	cpCtx.reputationChanges++
+	return sequence
+}
@@ -0,0 +1,494 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// =============================================================================
+// ChainProof - Append-Only Custody Log
+// =============================================================================
+// Custody entries are stored as their own records under the composite key
+// custody~evidenceId~sequence and are never rewritten. A small head record
+// tracks the next sequence and the hash of the last entry, so each entry
+// links to its predecessor. The Evidence document no longer embeds the log.
+//
+// EntryHash = SHA256(entry JSON with entryHash empty), and PrevHash carries the
+// predecessor's EntryHash, so altering any entry breaks every later link.
+//
+// Evidence written before this layout embeds its log in the document. The
+// first write to such evidence copies the embedded entries into the chain
+// (stamped with migratedTxId) before the document is stored without them;
+// until then, reads fall back to the embedded entries.
+// =============================================================================
+
+// Composite key object types
+const (
+	custodyEntryObjectType = "custody"
+	custodyHeadObjectType  = "custody_head"
+)
+
+// custodySequenceFormat zero-pads sequences so composite keys sort numerically
+const custodySequenceFormat = "%010d"
+
+// CustodyHead tracks the tip of an evidence item's custody chain
+type CustodyHead struct {
+	DocType    string `json:"docType"`    // "custody_head"
+	EvidenceID string `json:"evidenceId"` // Evidence this chain belongs to
+	Count      int    `json:"count"`      // Number of entries (next sequence)
+	LastHash   string `json:"lastHash"`   // Hash of the most recent entry
+
+	LegacyEntries int `json:"legacyEntries,omitempty"` // Leading entries migrated from the embedded log
+}
+
+// newCustodyLog builds a custody entry stamped with the current TxID and caller identity
+func newCustodyLog(
+	ctx contractapi.TransactionContextInterface,
+	action string,
+	description string,
+	timestamp int64,
+) CustodyLog {
+	callerOrg, _ := GetClientOrgID(ctx)
+	fingerprint, _ := GetClientFingerprint(ctx)
+
+	return CustodyLog{
+		DocType:          "custody_entry",
+		Action:           action,
+		ActorOrg:         callerOrg,
+		ActorFingerprint: fingerprint,
+		TxID:             ctx.GetStub().GetTxID(),
+		Timestamp:        timestamp,
+		Description:      description,
+	}
+}
+
+// appendCustodyLog writes a new custody entry for evidenceId and advances the chain head
+func appendCustodyLog(
+	ctx contractapi.TransactionContextInterface,
+	evidenceId string,
+	action string,
+	description string,
+	timestamp int64,
+) error {
+	head, err := migrateLegacyCustodyLog(ctx, evidenceId)
+	if err != nil {
+		return err
+	}
+
+	entry := newCustodyLog(ctx, action, description, timestamp)
+	entry.EvidenceID = evidenceId
+	entry.Sequence = head.Count
+	entry.PrevHash = head.LastHash
+	entry.EntryHash, err = computeCustodyEntryHash(entry)
+	if err != nil {
+		return err
+	}
+
+	if err := putCustodyEntry(ctx, &entry); err != nil {
+		return err
+	}
+
+	head.Count++
+	head.LastHash = entry.EntryHash
+
+	return putCustodyHead(ctx, head)
+}
+
+// putCustodyEntry stores an entry under custody~evidenceId~sequence
+func putCustodyEntry(ctx contractapi.TransactionContextInterface, entry *CustodyLog) error {
+	entryJSON, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal custody entry: %v", err)
+	}
+
+	entryKey, err := custodyEntryKey(ctx, entry.EvidenceID, entry.Sequence)
+	if err != nil {
+		return err
+	}
+	if err := ctx.GetStub().PutState(entryKey, entryJSON); err != nil {
+		return fmt.Errorf("failed to store custody entry: %v", err)
+	}
+	return nil
+}
+
+// migrateLegacyCustodyLog returns the chain head, first seeding the chain from the custody log
+// embedded in the committed evidence document when the evidence has no chain yet
+func migrateLegacyCustodyLog(
+	ctx contractapi.TransactionContextInterface,
+	evidenceId string,
+) (*CustodyHead, error) {
+	head, err := getCustodyHead(ctx, evidenceId)
+	if err != nil {
+		return nil, err
+	}
+	if head.Count > 0 {
+		return head, nil
+	}
+
+	legacy, err := getLegacyCustodyLog(ctx, evidenceId)
+	if err != nil {
+		return nil, err
+	}
+	if len(legacy) == 0 {
+		return head, nil
+	}
+
+	txID := ctx.GetStub().GetTxID()
+	for _, entry := range legacy {
+		entry.DocType = "custody_entry"
+		entry.PrevHash = head.LastHash
+		entry.MigratedTxID = txID
+		if entry.EntryHash, err = computeCustodyEntryHash(entry); err != nil {
+			return nil, err
+		}
+		if err := putCustodyEntry(ctx, &entry); err != nil {
+			return nil, err
+		}
+		head.Count++
+		head.LastHash = entry.EntryHash
+	}
+	head.LegacyEntries = len(legacy)
+
+	if err := putCustodyHead(ctx, head); err != nil {
+		return nil, err
+	}
+	return head, nil
+}
+
+// getLegacyCustodyLog returns the custody log embedded in the committed evidence document
+// It reads committed state on purpose: a pending write in this transaction no longer carries the log.
+func getLegacyCustodyLog(
+	ctx contractapi.TransactionContextInterface,
+	evidenceId string,
+) ([]CustodyLog, error) {
+	evidenceJSON, err := ctx.GetStub().GetState(evidenceId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read evidence %s: %v", evidenceId, err)
+	}
+	if evidenceJSON == nil {
+		return nil, nil
+	}
+	return embeddedCustodyLog(evidenceJSON, evidenceId)
+}
+
+// embeddedCustodyLog decodes the custody log embedded in an evidence document, numbered in order
+func embeddedCustodyLog(evidenceJSON []byte, evidenceId string) ([]CustodyLog, error) {
+	var document struct {
+		CustodyLog []CustodyLog `json:"custodyLog"`
+	}
+	if err := json.Unmarshal(evidenceJSON, &document); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal evidence: %v", err)
+	}
+
+	for i := range document.CustodyLog {
+		document.CustodyLog[i].EvidenceID = evidenceId
+		document.CustodyLog[i].Sequence = i
+	}
+	return document.CustodyLog, nil
+}
+
+// computeCustodyEntryHash hashes an entry's content (everything except EntryHash itself)
+func computeCustodyEntryHash(entry CustodyLog) (string, error) {
+	entry.EntryHash = ""
+	contentJSON, err := json.Marshal(entry)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal custody entry: %v", err)
+	}
+	hash := sha256.Sum256(contentJSON)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// verifyCustodyChain recomputes the chain for evidenceId and cross-checks it against ledger history.
+// Checks stop at the first divergence, which is reported with its sequence and TxID.
+func verifyCustodyChain(
+	ctx contractapi.TransactionContextInterface,
+	evidenceId string,
+) (*CustodyChainReport, error) {
+	if _, err := getEvidence(ctx, evidenceId); err != nil {
+		return nil, err
+	}
+
+	timestamp, err := getTxTimestamp(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := loadCustodyLog(ctx, evidenceId)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &CustodyChainReport{
+		EvidenceID: evidenceId,
+		EntryCount: len(entries),
+		CheckedAt:  timestamp,
+		Valid:      true,
+	}
+	if len(entries) == 0 {
+		// Not migrated yet: the embedded log has no hashes to check
+		legacy, err := getLegacyCustodyLog(ctx, evidenceId)
+		if err != nil {
+			return nil, err
+		}
+		report.LegacyEntries = len(legacy)
+	}
+	fail := func(sequence int, txID string, reason string) (*CustodyChainReport, error) {
+		report.Valid = false
+		report.FirstDivergence = &CustodyDivergence{Sequence: sequence, TxID: txID, Reason: reason}
+		return report, nil
+	}
+
+	// 1. Recompute the hash chain and check each entry was written exactly once by its own transaction
+	prevHash := ""
+	custodyTxIDs := map[string]bool{}
+	for i, entry := range entries {
+		if entry.Sequence != i {
+			return fail(i, entry.TxID, fmt.Sprintf("sequence gap: expected %d, found %d", i, entry.Sequence))
+		}
+		if entry.EvidenceID != evidenceId {
+			return fail(i, entry.TxID, fmt.Sprintf("entry belongs to evidence %s", entry.EvidenceID))
+		}
+		if entry.PrevHash != prevHash {
+			return fail(i, entry.TxID, fmt.Sprintf("prevHash %s does not match previous entry hash %s", entry.PrevHash, prevHash))
+		}
+		computedHash, err := computeCustodyEntryHash(entry)
+		if err != nil {
+			return nil, err
+		}
+		if computedHash != entry.EntryHash {
+			return fail(i, entry.TxID, fmt.Sprintf("entryHash %s does not match recomputed content hash %s", entry.EntryHash, computedHash))
+		}
+
+		entryKey, err := custodyEntryKey(ctx, evidenceId, i)
+		if err != nil {
+			return nil, err
+		}
+		if reason, err := checkCustodyEntryHistory(ctx, entryKey, entry); err != nil {
+			return nil, err
+		} else if reason != "" {
+			return fail(i, entry.TxID, reason)
+		}
+
+		prevHash = entry.EntryHash
+		custodyTxIDs[entry.TxID] = true
+	}
+	report.HeadHash = prevHash
+
+	// 2. The head must point at the last entry, and every historical head must match the entry it advanced to
+	head, err := getCustodyHead(ctx, evidenceId)
+	if err != nil {
+		return nil, err
+	}
+	if head.Count != len(entries) || head.LastHash != prevHash {
+		return fail(len(entries), "", fmt.Sprintf("chain head (count=%d, hash=%s) does not match entries (count=%d, hash=%s)",
+			head.Count, head.LastHash, len(entries), prevHash))
+	}
+	if head.Count > 0 {
+		report.LegacyEntries = head.LegacyEntries
+	}
+
+	headKey, err := ctx.GetStub().CreateCompositeKey(custodyHeadObjectType, []string{evidenceId})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create custody head key: %v", err)
+	}
+	headHistory, err := ctx.GetStub().GetHistoryForKey(headKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get custody head history for %s: %v", evidenceId, err)
+	}
+	defer headHistory.Close()
+
+	for headHistory.HasNext() {
+		modification, err := headHistory.Next()
+		if err != nil {
+			return nil, err
+		}
+		if modification.IsDelete {
+			return fail(-1, modification.TxId, "custody chain head was deleted")
+		}
+
+		var historicHead CustodyHead
+		if err := json.Unmarshal(modification.Value, &historicHead); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal custody head history: %v", err)
+		}
+		if historicHead.Count < 1 || historicHead.Count > len(entries) {
+			return fail(historicHead.Count-1, modification.TxId, fmt.Sprintf("historic chain head count %d has no matching entry", historicHead.Count))
+		}
+		tip := entries[historicHead.Count-1]
+		if tip.EntryHash != historicHead.LastHash || custodyWriterTxID(tip) != modification.TxId {
+			return fail(tip.Sequence, tip.TxID, fmt.Sprintf("historic chain head written in tx %s does not match entry hash", modification.TxId))
+		}
+	}
+
+	// 3. Every transaction that modified the evidence document must have left a custody entry
+	evidenceHistory, err := ctx.GetStub().GetHistoryForKey(evidenceId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get history for %s: %v", evidenceId, err)
+	}
+	defer evidenceHistory.Close()
+
+	for evidenceHistory.HasNext() {
+		modification, err := evidenceHistory.Next()
+		if err != nil {
+			return nil, err
+		}
+		if !modification.IsDelete {
+			// Versions written before migration carry their log inline and predate the chain
+			if legacy, err := embeddedCustodyLog(modification.Value, evidenceId); err != nil {
+				return nil, err
+			} else if len(legacy) > 0 {
+				continue
+			}
+		}
+		if !custodyTxIDs[modification.TxId] {
+			return fail(-1, modification.TxId, "evidence was modified by a transaction with no custody entry")
+		}
+	}
+
+	return report, nil
+}
+
+// checkCustodyEntryHistory confirms an entry key was written once, by the entry's own tx, and never changed
+func checkCustodyEntryHistory(
+	ctx contractapi.TransactionContextInterface,
+	entryKey string,
+	entry CustodyLog,
+) (string, error) {
+	history, err := ctx.GetStub().GetHistoryForKey(entryKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to get custody entry history: %v", err)
+	}
+	defer history.Close()
+
+	writes := 0
+	for history.HasNext() {
+		modification, err := history.Next()
+		if err != nil {
+			return "", err
+		}
+		writes++
+		if modification.IsDelete {
+			return fmt.Sprintf("entry was deleted in tx %s", modification.TxId), nil
+		}
+		if writer := custodyWriterTxID(entry); modification.TxId != writer {
+			return fmt.Sprintf("entry was written by tx %s, but records tx %s", modification.TxId, writer), nil
+		}
+		if entry.MigratedTxID == "" && modification.Timestamp != nil && modification.Timestamp.GetSeconds() != entry.Timestamp {
+			return fmt.Sprintf("entry timestamp %d does not match ledger timestamp %d", entry.Timestamp, modification.Timestamp.GetSeconds()), nil
+		}
+	}
+	if writes != 1 {
+		return fmt.Sprintf("entry was written %d times, expected exactly once", writes), nil
+	}
+
+	return "", nil
+}
+
+// custodyWriterTxID returns the transaction that stored an entry (the migration tx for legacy entries)
+func custodyWriterTxID(entry CustodyLog) string {
+	if entry.MigratedTxID != "" {
+		return entry.MigratedTxID
+	}
+	return entry.TxID
+}
+
+// loadCustodyLog assembles the full custody chain for evidenceId in sequence order
+func loadCustodyLog(
+	ctx contractapi.TransactionContextInterface,
+	evidenceId string,
+) ([]CustodyLog, error) {
+	resultsIterator, err := ctx.GetStub().GetStateByPartialCompositeKey(custodyEntryObjectType, []string{evidenceId})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read custody log for %s: %v", evidenceId, err)
+	}
+	defer resultsIterator.Close()
+
+	entries := []CustodyLog{}
+	for resultsIterator.HasNext() {
+		queryResult, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		var entry CustodyLog
+		if err := json.Unmarshal(queryResult.Value, &entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// getEvidenceWithCustody retrieves evidence with its custody log assembled
+func getEvidenceWithCustody(
+	ctx contractapi.TransactionContextInterface,
+	evidenceId string,
+) (*Evidence, error) {
+	evidence, err := getEvidence(ctx, evidenceId)
+	if err != nil {
+		return nil, err
+	}
+
+	custodyLog, err := loadCustodyLog(ctx, evidenceId)
+	if err != nil {
+		return nil, err
+	}
+	if len(custodyLog) == 0 {
+		// Not migrated yet: keep the embedded log, numbered like chain entries
+		if custodyLog, err = getLegacyCustodyLog(ctx, evidenceId); err != nil {
+			return nil, err
+		}
+	}
+	evidence.CustodyLog = custodyLog
+
+	return evidence, nil
+}
+
+// custodyEntryKey builds the composite key custody~evidenceId~sequence
+func custodyEntryKey(ctx contractapi.TransactionContextInterface, evidenceId string, sequence int) (string, error) {
+	key, err := ctx.GetStub().CreateCompositeKey(custodyEntryObjectType, []string{evidenceId, fmt.Sprintf(custodySequenceFormat, sequence)})
+	if err != nil {
+		return "", fmt.Errorf("failed to create custody key: %v", err)
+	}
+	return key, nil
+}
+
+// getCustodyHead reads the chain head, returning an empty head for a new chain
+func getCustodyHead(ctx contractapi.TransactionContextInterface, evidenceId string) (*CustodyHead, error) {
+	headKey, err := ctx.GetStub().CreateCompositeKey(custodyHeadObjectType, []string{evidenceId})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create custody head key: %v", err)
+	}
+
+	headJSON, err := readState(ctx, headKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read custody head for %s: %v", evidenceId, err)
+	}
+	if headJSON == nil {
+		return &CustodyHead{DocType: "custody_head", EvidenceID: evidenceId}, nil
+	}
+
+	var head CustodyHead
+	if err := json.Unmarshal(headJSON, &head); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal custody head: %v", err)
+	}
+	return &head, nil
+}
+
+// putCustodyHead stores the chain head
+func putCustodyHead(ctx contractapi.TransactionContextInterface, head *CustodyHead) error {
+	headKey, err := ctx.GetStub().CreateCompositeKey(custodyHeadObjectType, []string{head.EvidenceID})
+	if err != nil {
+		return fmt.Errorf("failed to create custody head key: %v", err)
+	}
+
+	headJSON, err := json.Marshal(head)
+	if err != nil {
+		return fmt.Errorf("failed to marshal custody head: %v", err)
+	}
+	return writeState(ctx, headKey, headJSON)
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// custodyCaller is the identity that records custody entries in these tests
+var custodyCaller = &testIdentity{mspID: VerifierOrgMSP, id: "verifier1"}
+
+// recordCustody stores evidence in its own transaction and, unless silent, logs action for it
+func recordCustody(t *testing.T, s *ledgerStub, txID string, evidence *Evidence, action string, silent bool) {
+	s.begin(txID)
+	ctx := newTestContext(s, custodyCaller)
+	if err := putEvidence(ctx, evidence); err != nil {
+		t.Fatal(err)
+	}
+	if silent {
+		return
+	}
+	if err := appendCustodyLog(ctx, evidence.EvidenceID, action, action+" by test", s.now); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// rewriteCustodyEntry replaces a stored entry in its own transaction, optionally rehashing it
+func rewriteCustodyEntry(t *testing.T, s *ledgerStub, evidenceId string, sequence int, rehash bool) {
+	s.begin("txTamper")
+	ctx := newTestContext(s, custodyCaller)
+	key, err := custodyEntryKey(ctx, evidenceId, sequence)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entry CustodyLog
+	if err := json.Unmarshal(s.State[key], &entry); err != nil {
+		t.Fatal(err)
+	}
+
+	entry.Description = "rewritten"
+	if rehash {
+		if entry.EntryHash, err = computeCustodyEntryHash(entry); err != nil {
+			t.Fatal(err)
+		}
+		head, err := getCustodyHead(ctx, evidenceId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		head.LastHash = entry.EntryHash
+		if err := putCustodyHead(ctx, head); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := putCustodyEntry(ctx, &entry); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifyCustodyChain(t *testing.T) {
+	const evidenceId = "EVD1"
+
+	// Every case starts from evidence submitted in tx1 and verified in tx2
+	tests := []struct {
+		name         string
+		tamper       func(t *testing.T, s *ledgerStub)
+		wantEntries  int
+		wantSequence int
+		wantTxID     string
+		wantReason   string // Substring of the divergence reason; empty when the chain is valid
+	}{
+		{
+			name:        "intact chain",
+			tamper:      func(t *testing.T, s *ledgerStub) {},
+			wantEntries: 2,
+		},
+		{
+			name: "further custody entry",
+			tamper: func(t *testing.T, s *ledgerStub) {
+				recordCustody(t, s, "tx3", &Evidence{EvidenceID: evidenceId, Status: StatusUnderReview}, "REVIEW", false)
+			},
+			wantEntries: 3,
+		},
+		{
+			name: "entry content altered",
+			tamper: func(t *testing.T, s *ledgerStub) {
+				rewriteCustodyEntry(t, s, evidenceId, 0, false)
+			},
+			wantEntries:  2,
+			wantSequence: 0,
+			wantTxID:     "tx1",
+			wantReason:   "does not match recomputed content hash",
+		},
+		{
+			name: "last entry rewritten with a fresh hash",
+			tamper: func(t *testing.T, s *ledgerStub) {
+				rewriteCustodyEntry(t, s, evidenceId, 1, true)
+			},
+			wantEntries:  2,
+			wantSequence: 1,
+			wantTxID:     "tx2",
+			wantReason:   "entry was written by tx txTamper",
+		},
+		{
+			name: "evidence modified without a custody entry",
+			tamper: func(t *testing.T, s *ledgerStub) {
+				recordCustody(t, s, "tx3", &Evidence{EvidenceID: evidenceId, Status: StatusExported}, "", true)
+			},
+			wantEntries:  2,
+			wantSequence: -1,
+			wantTxID:     "tx3",
+			wantReason:   "modified by a transaction with no custody entry",
+		},
+		{
+			name: "chain head deleted",
+			tamper: func(t *testing.T, s *ledgerStub) {
+				s.begin("txTamper")
+				headKey, _ := s.CreateCompositeKey(custodyHeadObjectType, []string{evidenceId})
+				if err := s.DelState(headKey); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantEntries:  2,
+			wantSequence: 2,
+			wantReason:   "chain head (count=0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newLedgerStub()
+			recordCustody(t, s, "tx1", &Evidence{EvidenceID: evidenceId, Status: StatusSubmitted}, "SUBMIT", false)
+			recordCustody(t, s, "tx2", &Evidence{EvidenceID: evidenceId, Status: StatusVerified}, "VERIFY", false)
+			tt.tamper(t, s)
+
+			s.begin("txCheck")
+			report, err := verifyCustodyChain(newTestContext(s, custodyCaller), evidenceId)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if report.EntryCount != tt.wantEntries {
+				t.Fatalf("entry count %d, want %d", report.EntryCount, tt.wantEntries)
+			}
+
+			if tt.wantReason == "" {
+				if !report.Valid || report.FirstDivergence != nil {
+					t.Fatalf("chain reported invalid: %+v", report.FirstDivergence)
+				}
+				if report.HeadHash == "" {
+					t.Fatal("valid chain reported no head hash")
+				}
+				return
+			}
+
+			divergence := report.FirstDivergence
+			if report.Valid || divergence == nil {
+				t.Fatal("tampered chain reported valid")
+			}
+			if divergence.Sequence != tt.wantSequence || divergence.TxID != tt.wantTxID {
+				t.Fatalf("divergence at sequence %d tx %q, want sequence %d tx %q", divergence.Sequence, divergence.TxID, tt.wantSequence, tt.wantTxID)
+			}
+			if !strings.Contains(divergence.Reason, tt.wantReason) {
+				t.Fatalf("reason %q does not contain %q", divergence.Reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestVerifyCustodyChainLegacyLog(t *testing.T) {
+	const evidenceId = "EVD1"
+
+	tests := []struct {
+		name        string
+		migrate     bool
+		wantEntries int
+	}{
+		{"embedded log not yet migrated", false, 0},
+		{"embedded log migrated on the next write", true, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newLedgerStub()
+
+			// Write the document the way evidence was stored before the chain existed
+			s.begin("txLegacy")
+			legacyJSON, err := json.Marshal(Evidence{
+				EvidenceID: evidenceId,
+				Status:     StatusVerified,
+				CustodyLog: []CustodyLog{
+					{Action: "SUBMIT", TxID: "txOld1", Timestamp: 1600000000},
+					{Action: "VERIFY", TxID: "txOld2", Timestamp: 1600000100},
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := s.PutState(evidenceId, legacyJSON); err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.migrate {
+				recordCustody(t, s, "tx1", &Evidence{EvidenceID: evidenceId, Status: StatusUnderReview}, "REVIEW", false)
+			}
+
+			s.begin("txCheck")
+			report, err := verifyCustodyChain(newTestContext(s, custodyCaller), evidenceId)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !report.Valid {
+				t.Fatalf("chain reported invalid: %+v", report.FirstDivergence)
+			}
+			if report.EntryCount != tt.wantEntries || report.LegacyEntries != 2 {
+				t.Fatalf("%d entries (%d legacy), want %d (2 legacy)", report.EntryCount, report.LegacyEntries, tt.wantEntries)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Package events defines the chaincode events emitted by ChainProof.
+//
+// Every transaction that changes evidence emits one Fabric chaincode event
+// (Fabric keeps only one per transaction). Its name is the Type of the first
+// change in the transaction and its payload is an Envelope listing every
+// change, in order. Payloads carry only data that is already public on the
+// ledger: no publicKeyHash, notification text, notes, comments or
+// justifications. Clients decode the payload with encoding/json and should
+// check SchemaVersion before relying on fields added in later versions.
+package events
+
+// SchemaVersion is the version of the Envelope layout
+// It increases when fields change meaning or are removed; added fields keep the version.
+const SchemaVersion = 1
+
+// Event types, also used as chaincode event names
+const (
+	EvidenceSubmitted     = "EvidenceSubmitted"     // SubmitEvidence
+	EvidenceBulkSubmitted = "EvidenceBulkSubmitted" // One per item of SubmitBulkEvidence
+	EvidenceVerified      = "EvidenceVerified"      // Verification quorum confirmed the hash
+	EvidenceRejected      = "EvidenceRejected"      // Verification quorum found a mismatch
+	EvidenceDisputed      = "EvidenceDisputed"      // Verifiers disagreed, new round opened
+	EvidenceAppealed      = "EvidenceAppealed"      // Submitter appealed a rejection
+	EvidenceWithdrawn     = "EvidenceWithdrawn"     // Submitter retracted the evidence
+	EvidenceSuperseded    = "EvidenceSuperseded"    // Replaced by a newer version
+	ReviewStarted         = "ReviewStarted"         // Legal review began
+	ReviewCompleted       = "ReviewCompleted"       // Legal review finished with a verdict
+	ReviewReopened        = "ReviewReopened"        // Completed review reopened
+	HashMismatchReported  = "HashMismatchReported"  // Legal team found a hash mismatch
+	ExportRequested       = "ExportRequested"       // First identity requested a court export
+	EvidenceExported      = "EvidenceExported"      // Second identity approved the export
+	AnchorRecorded        = "AnchorRecorded"        // Public blockchain anchor stored
+	NoteAdded             = "NoteAdded"             // Private verification note stored (content not included)
+	CommentAdded          = "CommentAdded"          // Private legal comment stored (content not included)
+	NotificationSent      = "NotificationSent"      // Notification queued for the submitter or an organization
+)
+
+// Envelope is the payload of a ChainProof chaincode event
+type Envelope struct {
+	SchemaVersion int      `json:"schemaVersion"` // SchemaVersion at emission time
+	TxID          string   `json:"txId"`          // Transaction that made the changes
+	ActorOrg      string   `json:"actorOrg"`      // MSP ID of the submitting client
+	Timestamp     int64    `json:"timestamp"`     // Transaction timestamp (Unix seconds)
+	Events        []*Event `json:"events"`        // Changes in the order they were made
+}
+
+// Event is one state change within a transaction
+type Event struct {
+	Type       string `json:"type"`       // One of the event type constants
+	EvidenceID string `json:"evidenceId"` // Evidence the change applies to
+	OldStatus  string `json:"oldStatus"`  // Status before the change ("" for new evidence or when not tracked)
+	NewStatus  string `json:"newStatus"`  // Status after the change ("" when not tracked)
+	ActorOrg   string `json:"actorOrg"`   // MSP ID of the submitting client
+	TxID       string `json:"txId"`       // Transaction that made the change
+
+	BulkSubmissionID string `json:"bulkSubmissionId,omitempty"` // EvidenceBulkSubmitted
+	Verdict          string `json:"verdict,omitempty"`          // ReviewCompleted
+	NotificationType string `json:"notificationType,omitempty"` // NotificationSent
+	RecipientOrg     string `json:"recipientOrg,omitempty"`     // NotificationSent to an organization ("" = the submitter)
+}
@@ -0,0 +1,292 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// =============================================================================
+// ChainProof - Court Export Records
+// =============================================================================
+// Every export is stored as its own immutable record under
+// export~evidenceId~sequence, so re-exports never overwrite earlier ones.
+// Records are only issued through the four-eyes approval in export_approval.go.
+// ExportHash = SHA256(record JSON with exportHash empty). A court holding an
+// export JSON can check it with VerifyExport, which recomputes the hash and
+// compares the record with the stored export and the current evidence.
+// =============================================================================
+
+// exportObjectType is the composite key object type for export records
+const exportObjectType = "export"
+
+// exportSequenceFormat zero-pads sequences so composite keys sort numerically
+const exportSequenceFormat = "%06d"
+
+// VerifyExport checks an export JSON handed over outside the ledger
+func (c *QueryContract) VerifyExport(
+	ctx contractapi.TransactionContextInterface,
+	exportJson string,
+) (*ExportVerificationReport, error) {
+	if err := RequireAnyOrg(ctx); err != nil {
+		return nil, err
+	}
+
+	var record ExportRecord
+	if err := json.Unmarshal([]byte(exportJson), &record); err != nil {
+		return nil, fmt.Errorf("failed to parse export JSON: %v", err)
+	}
+	if record.EvidenceID == "" || record.ExportSequence < 1 {
+		return nil, fmt.Errorf("export JSON must include evidenceId and exportSequence")
+	}
+
+	timestamp, err := getTxTimestamp(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ExportVerificationReport{
+		EvidenceID:     record.EvidenceID,
+		ExportSequence: record.ExportSequence,
+		Discrepancies:  []string{},
+		CheckedAt:      timestamp,
+	}
+
+	// 1. The hash covers the content as presented
+	computed, err := computeExportHash(record)
+	if err != nil {
+		return nil, err
+	}
+	report.HashValid = strings.EqualFold(computed, record.ExportHash)
+	if !report.HashValid {
+		report.Discrepancies = append(report.Discrepancies,
+			fmt.Sprintf("exportHash %s does not match content hash %s", record.ExportHash, computed))
+	}
+
+	// 2. The ledger holds the same export
+	stored, err := getExportRecord(ctx, record.EvidenceID, record.ExportSequence)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		report.Discrepancies = append(report.Discrepancies,
+			fmt.Sprintf("no export %d is stored for evidence %s", record.ExportSequence, record.EvidenceID))
+	} else if stored.ExportHash != computed {
+		report.Discrepancies = append(report.Discrepancies,
+			fmt.Sprintf("stored export hash %s differs from content hash %s", stored.ExportHash, computed))
+	} else {
+		report.MatchesStoredRecord = true
+	}
+
+	// 3. The evidence has not moved on since
+	exists, err := evidenceExists(ctx, record.EvidenceID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		report.Discrepancies = append(report.Discrepancies, fmt.Sprintf("evidence %s does not exist", record.EvidenceID))
+	} else {
+		evidence, err := getEvidenceWithCustody(ctx, record.EvidenceID)
+		if err != nil {
+			return nil, err
+		}
+		mismatches := compareExportWithEvidence(&record, evidence)
+		report.MatchesCurrentEvidence = len(mismatches) == 0
+		report.Discrepancies = append(report.Discrepancies, mismatches...)
+		report.IsLatestExport = record.ExportSequence == evidence.ExportCount
+	}
+
+	report.Valid = report.HashValid && report.MatchesStoredRecord && report.MatchesCurrentEvidence
+	return report, nil
+}
+
+// GetExportRecords returns every stored export of an evidence item, oldest first
+func (c *QueryContract) GetExportRecords(
+	ctx contractapi.TransactionContextInterface,
+	evidenceId string,
+) ([]*ExportRecord, error) {
+	if err := RequireAnyOrg(ctx); err != nil {
+		return nil, err
+	}
+
+	resultsIterator, err := ctx.GetStub().GetStateByPartialCompositeKey(exportObjectType, []string{evidenceId})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read export records for %s: %v", evidenceId, err)
+	}
+	defer resultsIterator.Close()
+
+	records := []*ExportRecord{}
+	for resultsIterator.HasNext() {
+		queryResult, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		var record ExportRecord
+		if err := json.Unmarshal(queryResult.Value, &record); err != nil {
+			return nil, err
+		}
+		records = append(records, &record)
+	}
+
+	return records, nil
+}
+
+// issueExport builds, hashes and stores the next export record for an approved request
+// evidence must carry its custody log and have already taken the EXPORT transition.
+func issueExport(
+	ctx contractapi.TransactionContextInterface,
+	evidence *Evidence,
+	request *ExportRequest,
+	approver string,
+	timestamp int64,
+) (*ExportRecord, error) {
+	// Court exports carry every version of the evidence
+	lineage, err := loadEvidenceLineage(ctx, evidence.EvidenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	callerOrg, _ := GetClientOrgID(ctx)
+	exportRecord := ExportRecord{
+		EvidenceID:      evidence.EvidenceID,
+		IPFSCID:         evidence.IPFSCID,
+		FileHash:        evidence.FileHash,
+		FileType:        evidence.FileType,
+		Category:        evidence.Category,
+		SubmittedAt:     evidence.SubmittedAt,
+		VerifiedAt:      evidence.VerifiedAt,
+		ReviewedAt:      evidence.ReviewedAt,
+		ExportedAt:      timestamp,
+		PolygonTxHash:   evidence.PolygonTxHash,
+		IntegrityStatus: evidence.IntegrityStatus,
+		Verdict:         evidence.Verdict,
+		CustodyLog:      evidence.CustodyLog,
+		Lineage:         lineage.Versions,
+		ExportSequence:  evidence.ExportCount + 1,
+		ExportedBy:      callerOrg,
+		RequestID:       request.RequestID,
+		RequestedBy:     request.RequestedBy,
+		ApprovedBy:      approver,
+		TxID:            ctx.GetStub().GetTxID(),
+	}
+
+	// Generate hash of export record for integrity
+	exportRecord.ExportHash, err = computeExportHash(exportRecord)
+	if err != nil {
+		return nil, err
+	}
+	if err := putExportRecord(ctx, &exportRecord); err != nil {
+		return nil, err
+	}
+
+	// Update evidence export time (status already moved by the transition table)
+	evidence.ExportedAt = timestamp
+	evidence.ExportCount = exportRecord.ExportSequence
+	evidence.ExportRequestID = ""
+	if err := putEvidence(ctx, evidence); err != nil {
+		return nil, err
+	}
+
+	if err := appendCustodyLog(ctx, evidence.EvidenceID, ActionExport,
+		fmt.Sprintf("Evidence exported for court proceedings (export %d), requested by %s and approved by %s. Export hash: %s",
+			exportRecord.ExportSequence, request.RequestedBy, approver, exportRecord.ExportHash), timestamp); err != nil {
+		return nil, err
+	}
+
+	return &exportRecord, nil
+}
+
+// compareExportWithEvidence lists the ways an export no longer reflects the evidence
+func compareExportWithEvidence(record *ExportRecord, evidence *Evidence) []string {
+	mismatches := []string{}
+	if evidence.Status != StatusExported {
+		mismatches = append(mismatches, fmt.Sprintf("evidence status is %s, not %s", evidence.Status, StatusExported))
+	}
+	if record.FileHash != evidence.FileHash {
+		mismatches = append(mismatches, fmt.Sprintf("fileHash %s differs from ledger %s", record.FileHash, evidence.FileHash))
+	}
+	if record.IPFSCID != evidence.IPFSCID {
+		mismatches = append(mismatches, fmt.Sprintf("ipfsCid %s differs from ledger %s", record.IPFSCID, evidence.IPFSCID))
+	}
+
+	// The exported custody log must be a prefix of the current chain
+	if len(record.CustodyLog) > len(evidence.CustodyLog) {
+		mismatches = append(mismatches, fmt.Sprintf("export holds %d custody entries, ledger only %d",
+			len(record.CustodyLog), len(evidence.CustodyLog)))
+		return mismatches
+	}
+	for i, entry := range record.CustodyLog {
+		if entry.EntryHash != evidence.CustodyLog[i].EntryHash {
+			mismatches = append(mismatches, fmt.Sprintf("custody entry %d differs from ledger", entry.Sequence))
+			break
+		}
+	}
+	return mismatches
+}
+
+// computeExportHash hashes an export record's content (everything except ExportHash itself)
+func computeExportHash(record ExportRecord) (string, error) {
+	record.ExportHash = ""
+	contentJSON, err := json.Marshal(record)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal export record: %v", err)
+	}
+	hash := sha256.Sum256(contentJSON)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// exportRecordKey builds the composite key export~evidenceId~sequence
+func exportRecordKey(ctx contractapi.TransactionContextInterface, evidenceId string, sequence int) (string, error) {
+	key, err := ctx.GetStub().CreateCompositeKey(exportObjectType, []string{evidenceId, fmt.Sprintf(exportSequenceFormat, sequence)})
+	if err != nil {
+		return "", fmt.Errorf("failed to create export key: %v", err)
+	}
+	return key, nil
+}
+
+// getExportRecord reads a stored export, returning nil when it does not exist
+func getExportRecord(ctx contractapi.TransactionContextInterface, evidenceId string, sequence int) (*ExportRecord, error) {
+	key, err := exportRecordKey(ctx, evidenceId, sequence)
+	if err != nil {
+		return nil, err
+	}
+	recordJSON, err := readState(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read export record: %v", err)
+	}
+	if recordJSON == nil {
+		return nil, nil
+	}
+
+	var record ExportRecord
+	if err := json.Unmarshal(recordJSON, &record); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal export record: %v", err)
+	}
+	return &record, nil
+}
+
+// putExportRecord stores a new export, refusing to overwrite an existing sequence
+func putExportRecord(ctx contractapi.TransactionContextInterface, record *ExportRecord) error {
+	existing, err := getExportRecord(ctx, record.EvidenceID, record.ExportSequence)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("export %d of evidence %s already exists", record.ExportSequence, record.EvidenceID)
+	}
+
+	key, err := exportRecordKey(ctx, record.EvidenceID, record.ExportSequence)
+	if err != nil {
+		return err
+	}
+	recordJSON, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal export record: %v", err)
+	}
+	return writeState(ctx, key, recordJSON)
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// =============================================================================
+// ChainProof - Four-Eyes Export Approval
+// =============================================================================
+// No single LegalOrg identity can issue a court export. One identity calls
+// RequestExport; a second, distinct identity calls ApproveExport within the
+// configured approval window, and only then is the ExportRecord issued. Both
+// certificate fingerprints are recorded on the request and the export record.
+// Expired requests are reported as EXPIRED and replaced by the next request.
+// =============================================================================
+
+// exportRequestObjectType is the composite key object type for export requests
+const exportRequestObjectType = "export_request"
+
+// RequestExport opens an export request that a second LegalOrg identity must approve
+func (c *LegalContract) RequestExport(
+	ctx contractapi.TransactionContextInterface,
+	evidenceId string,
+	reason string,
+) (*ExportRequest, error) {
+	if err := RequireLegalOrg(ctx, RoleExporter, RoleSupervisor); err != nil {
+		return nil, err
+	}
+
+	evidence, err := getEvidence(ctx, evidenceId)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := applyTransition(ctx, evidence, TransitionRequestExport); err != nil {
+		return nil, err
+	}
+
+	requester, err := GetClientFingerprint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	timestamp, err := getTxTimestamp(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := getParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only one open request per evidence item; one that expired or predates the current review is closed out
+	if evidence.ExportRequestID != "" {
+		previous, err := getExportRequest(ctx, evidenceId, evidence.ExportRequestID)
+		if err != nil {
+			return nil, err
+		}
+		if previous != nil && previous.Status == ExportRequestPending {
+			if timestamp <= previous.ExpiresAt && previous.ReviewedAt == evidence.ReviewedAt {
+				return nil, fmt.Errorf("export request %s for evidence %s is pending approval until %d",
+					previous.RequestID, evidenceId, previous.ExpiresAt)
+			}
+			previous.Status = ExportRequestExpired
+			if err := putExportRequest(ctx, previous); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	request := &ExportRequest{
+		DocType:     "export_request",
+		RequestID:   ctx.GetStub().GetTxID(),
+		EvidenceID:  evidenceId,
+		Status:      ExportRequestPending,
+		Reason:      reason,
+		RequestedBy: requester,
+		RequestedAt: timestamp,
+		ExpiresAt:   timestamp + params.ExportApprovalSeconds,
+		ReviewedAt:  evidence.ReviewedAt,
+	}
+	if err := putExportRequest(ctx, request); err != nil {
+		return nil, err
+	}
+
+	evidence.ExportRequestID = request.RequestID
+	if err := putEvidence(ctx, evidence); err != nil {
+		return nil, err
+	}
+
+	description := fmt.Sprintf("Export requested by %s (request %s), approval by a second identity due %d",
+		requester, request.RequestID, request.ExpiresAt)
+	if reason != "" {
+		description += fmt.Sprintf(" | Reason: %s", reason)
+	}
+	if err := appendCustodyLog(ctx, evidenceId, ActionExportRequest, description, timestamp); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// ApproveExport approves a pending export request from a different LegalOrg identity and issues the export record
+func (c *LegalContract) ApproveExport(
+	ctx contractapi.TransactionContextInterface,
+	evidenceId string,
+	requestId string,
+) (*ExportRecord, error) {
+	if err := RequireLegalOrg(ctx, RoleExporter, RoleSupervisor); err != nil {
+		return nil, err
+	}
+
+	evidence, err := getEvidenceWithCustody(ctx, evidenceId)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := getExportRequest(ctx, evidenceId, requestId)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil {
+		return nil, fmt.Errorf("export request %s for evidence %s does not exist", requestId, evidenceId)
+	}
+
+	approver, err := GetClientFingerprint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	timestamp, err := getTxTimestamp(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Status != ExportRequestPending || evidence.ExportRequestID != requestId {
+		return nil, fmt.Errorf("export request %s is %s, not awaiting approval", requestId, request.Status)
+	}
+	if timestamp > request.ExpiresAt {
+		return nil, fmt.Errorf("export request %s expired at %d; request the export again", requestId, request.ExpiresAt)
+	}
+	if approver == request.RequestedBy {
+		return nil, fmt.Errorf("export request %s must be approved by a different identity than the requester", requestId)
+	}
+	if evidence.ReviewedAt != request.ReviewedAt {
+		return nil, fmt.Errorf("evidence %s was reviewed again after export request %s; request the export again", evidenceId, requestId)
+	}
+
+	transition, err := applyTransition(ctx, evidence, TransitionExport)
+	if err != nil {
+		return nil, err
+	}
+
+	exportRecord, err := issueExport(ctx, evidence, request, approver, timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Count the submission as exported the first time it reaches court
+	if transition.From != StatusExported {
+		if _, err := applyReputationEvent(ctx, evidence.PublicKeyHash, reputationEvent{
+			EvidenceID: evidenceId,
+			Cause:      ReputationCauseExported,
+			Detail:     fmt.Sprintf("Evidence exported for court (export %d)", exportRecord.ExportSequence),
+		}, timestamp); err != nil {
+			return nil, fmt.Errorf("failed to update reputation: %v", err)
+		}
+	}
+
+	request.Status = ExportRequestApproved
+	request.ApprovedBy = approver
+	request.ApprovedAt = timestamp
+	request.ExportSequence = exportRecord.ExportSequence
+	request.ExportHash = exportRecord.ExportHash
+	if err := putExportRequest(ctx, request); err != nil {
+		return nil, err
+	}
+
+	return exportRecord, nil
+}
+
+// GetExportRequests returns every export request for an evidence item
+// Pending requests past their deadline are reported as EXPIRED.
+func (c *QueryContract) GetExportRequests(
+	ctx contractapi.TransactionContextInterface,
+	evidenceId string,
+) ([]*ExportRequest, error) {
+	if err := RequireAnyOrg(ctx); err != nil {
+		return nil, err
+	}
+
+	timestamp, err := getTxTimestamp(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resultsIterator, err := ctx.GetStub().GetStateByPartialCompositeKey(exportRequestObjectType, []string{evidenceId})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read export requests for %s: %v", evidenceId, err)
+	}
+	defer resultsIterator.Close()
+
+	requests := []*ExportRequest{}
+	for resultsIterator.HasNext() {
+		queryResult, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		var request ExportRequest
+		if err := json.Unmarshal(queryResult.Value, &request); err != nil {
+			return nil, err
+		}
+		if request.Status == ExportRequestPending && timestamp > request.ExpiresAt {
+			request.Status = ExportRequestExpired
+		}
+		requests = append(requests, &request)
+	}
+
+	return requests, nil
+}
+
+// exportRequestKey builds the composite key export_request~evidenceId~requestId
+func exportRequestKey(ctx contractapi.TransactionContextInterface, evidenceId string, requestId string) (string, error) {
+	key, err := ctx.GetStub().CreateCompositeKey(exportRequestObjectType, []string{evidenceId, requestId})
+	if err != nil {
+		return "", fmt.Errorf("failed to create export request key: %v", err)
+	}
+	return key, nil
+}
+
+// getExportRequest reads an export request, returning nil when it does not exist
+func getExportRequest(ctx contractapi.TransactionContextInterface, evidenceId string, requestId string) (*ExportRequest, error) {
+	key, err := exportRequestKey(ctx, evidenceId, requestId)
+	if err != nil {
+		return nil, err
+	}
+	requestJSON, err := readState(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read export request: %v", err)
+	}
+	if requestJSON == nil {
+		return nil, nil
+	}
+
+	var request ExportRequest
+	if err := json.Unmarshal(requestJSON, &request); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal export request: %v", err)
+	}
+	return &request, nil
+}
+
+// putExportRequest stores an export request
+func putExportRequest(ctx contractapi.TransactionContextInterface, request *ExportRequest) error {
+	key, err := exportRequestKey(ctx, request.EvidenceID, request.RequestID)
+	if err != nil {
+		return err
+	}
+	requestJSON, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to marshal export request: %v", err)
+	}
+	return writeState(ctx, key, requestJSON)
+}
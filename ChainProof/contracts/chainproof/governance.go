@@ -0,0 +1,412 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// =============================================================================
+// ChainProof - Multi-Org Governance
+// =============================================================================
+// The ledger parameters (including the governance rules themselves) and the
+// organization registry change only through proposals. Any submitter,
+// verifier or legal organization can propose; each of them casts one vote per
+// proposal through a supervisor identity. Once GovernanceApprovalPercent of the
+// voting organizations approve, the change is applied in the same transaction.
+// Proposals, votes and outcomes stay on the public ledger under
+// governance_proposal~proposalId and governance_vote~proposalId~mspId.
+// =============================================================================
+
+// Governance composite key object types
+const (
+	governanceProposalObjectType = "governance_proposal"
+	governanceVoteObjectType     = "governance_vote"
+)
+
+// GovernanceContract handles multi-organization configuration changes
+type GovernanceContract struct {
+	contractapi.Contract
+}
+
+// ProposeChange opens a proposal to change the ledger parameters or the organization registry
+// kind is PARAMS (changesJson is a partial parameter set, e.g. {"initialTrustScore":40})
+// or ORG_REGISTRY (changesJson is the complete registry).
+func (c *GovernanceContract) ProposeChange(
+	ctx contractapi.TransactionContextInterface,
+	kind string,
+	changesJson string,
+	description string,
+) (*GovernanceProposal, error) {
+	registry, err := getOrgRegistry(ctx)
+	if err != nil {
+		return nil, err
+	}
+	voters := votingMSPs(registry)
+	if err := requireGovernanceVoter(ctx, voters); err != nil {
+		return nil, err
+	}
+
+	// Reject changes that would not validate against the configuration as it stands
+	if err := checkProposalChanges(ctx, kind, changesJson); err != nil {
+		return nil, err
+	}
+
+	params, err := getParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	callerOrg, _ := GetClientOrgID(ctx)
+	proposer, err := GetClientFingerprint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	timestamp, err := getTxTimestamp(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	proposal := &GovernanceProposal{
+		DocType:           "governance_proposal",
+		ProposalID:        ctx.GetStub().GetTxID(),
+		Kind:              kind,
+		Changes:           changesJson,
+		Description:       description,
+		ProposedBy:        callerOrg,
+		ProposerID:        proposer,
+		Voters:            voters,
+		RequiredApprovals: requiredApprovals(len(voters), params.GovernanceApprovalPercent),
+		Status:            ProposalOpen,
+		CreatedAt:         timestamp,
+		ExpiresAt:         timestamp + params.GovernanceVotingSeconds,
+	}
+	if err := putGovernanceProposal(ctx, proposal); err != nil {
+		return nil, err
+	}
+
+	return proposal, nil
+}
+
+// VoteOnProposal records the calling organization's vote and applies the change once the majority approves
+func (c *GovernanceContract) VoteOnProposal(
+	ctx contractapi.TransactionContextInterface,
+	proposalId string,
+	approve bool,
+	comment string,
+) (*GovernanceProposal, error) {
+	proposal, err := getGovernanceProposal(ctx, proposalId)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireGovernanceVoter(ctx, proposal.Voters); err != nil {
+		return nil, err
+	}
+
+	timestamp, err := getTxTimestamp(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if proposal.Status != ProposalOpen {
+		return nil, fmt.Errorf("proposal %s is %s, not open for voting", proposalId, proposal.Status)
+	}
+	if timestamp > proposal.ExpiresAt {
+		return nil, fmt.Errorf("proposal %s expired at %d; propose the change again", proposalId, proposal.ExpiresAt)
+	}
+
+	callerOrg, _ := GetClientOrgID(ctx)
+	voteKey, err := ctx.GetStub().CreateCompositeKey(governanceVoteObjectType, []string{proposalId, callerOrg})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create governance vote key: %v", err)
+	}
+	existing, err := readState(ctx, voteKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read governance vote: %v", err)
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("organization %s has already voted on proposal %s", callerOrg, proposalId)
+	}
+
+	voter, err := GetClientFingerprint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	vote := GovernanceVote{
+		DocType:    "governance_vote",
+		ProposalID: proposalId,
+		VoterMSP:   callerOrg,
+		VoterID:    voter,
+		Approve:    approve,
+		Comment:    comment,
+		TxID:       ctx.GetStub().GetTxID(),
+		VotedAt:    timestamp,
+	}
+	voteJSON, err := json.Marshal(vote)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal governance vote: %v", err)
+	}
+	if err := writeState(ctx, voteKey, voteJSON); err != nil {
+		return nil, err
+	}
+
+	if approve {
+		proposal.Approvals++
+	} else {
+		proposal.Rejections++
+	}
+
+	switch {
+	case proposal.Approvals >= proposal.RequiredApprovals:
+		// An approved change that no longer validates is recorded as FAILED rather than rolling back the vote
+		if err := applyProposal(ctx, proposal, timestamp); err != nil {
+			proposal.Status = ProposalFailed
+			proposal.Outcome = fmt.Sprintf("approved by %d of %d organizations but could not be applied: %v",
+				proposal.Approvals, len(proposal.Voters), err)
+		} else {
+			proposal.Status = ProposalApplied
+			proposal.Outcome = fmt.Sprintf("approved by %d of %d organizations and applied",
+				proposal.Approvals, len(proposal.Voters))
+		}
+	case len(proposal.Voters)-proposal.Rejections < proposal.RequiredApprovals:
+		proposal.Status = ProposalRejected
+		proposal.Outcome = fmt.Sprintf("rejected by %d of %d organizations; %d approvals can no longer be reached",
+			proposal.Rejections, len(proposal.Voters), proposal.RequiredApprovals)
+	}
+	if proposal.Status != ProposalOpen {
+		proposal.DecidedAt = timestamp
+		proposal.DecidedTxID = ctx.GetStub().GetTxID()
+	}
+
+	if err := putGovernanceProposal(ctx, proposal); err != nil {
+		return nil, err
+	}
+	return proposal, nil
+}
+
+// GetProposal returns a proposal with its votes
+// An open proposal past its deadline is reported as EXPIRED.
+func (c *GovernanceContract) GetProposal(
+	ctx contractapi.TransactionContextInterface,
+	proposalId string,
+) (*GovernanceProposalDetail, error) {
+	if err := RequireAnyOrg(ctx); err != nil {
+		return nil, err
+	}
+
+	proposal, err := getGovernanceProposal(ctx, proposalId)
+	if err != nil {
+		return nil, err
+	}
+	timestamp, err := getTxTimestamp(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reportExpiry(proposal, timestamp)
+
+	resultsIterator, err := ctx.GetStub().GetStateByPartialCompositeKey(governanceVoteObjectType, []string{proposalId})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read votes for proposal %s: %v", proposalId, err)
+	}
+	defer resultsIterator.Close()
+
+	votes := []*GovernanceVote{}
+	for resultsIterator.HasNext() {
+		queryResult, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		var vote GovernanceVote
+		if err := json.Unmarshal(queryResult.Value, &vote); err != nil {
+			return nil, err
+		}
+		votes = append(votes, &vote)
+	}
+
+	return &GovernanceProposalDetail{Proposal: proposal, Votes: votes}, nil
+}
+
+// QueryProposals lists proposals, optionally filtered by status ("" for all), oldest first
+func (c *GovernanceContract) QueryProposals(
+	ctx contractapi.TransactionContextInterface,
+	status string,
+) ([]*GovernanceProposal, error) {
+	if err := RequireAnyOrg(ctx); err != nil {
+		return nil, err
+	}
+
+	timestamp, err := getTxTimestamp(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resultsIterator, err := ctx.GetStub().GetStateByPartialCompositeKey(governanceProposalObjectType, []string{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read governance proposals: %v", err)
+	}
+	defer resultsIterator.Close()
+
+	proposals := []*GovernanceProposal{}
+	for resultsIterator.HasNext() {
+		queryResult, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		var proposal GovernanceProposal
+		if err := json.Unmarshal(queryResult.Value, &proposal); err != nil {
+			return nil, err
+		}
+		reportExpiry(&proposal, timestamp)
+		if status != "" && proposal.Status != status {
+			continue
+		}
+		proposals = append(proposals, &proposal)
+	}
+
+	sort.SliceStable(proposals, func(i, j int) bool { return proposals[i].CreatedAt < proposals[j].CreatedAt })
+	return proposals, nil
+}
+
+// votingMSPs returns the organizations entitled to vote: every submitter, verifier and legal MSP
+func votingMSPs(registry *OrgRegistry) []string {
+	seen := map[string]bool{}
+	voters := []string{}
+	for _, role := range requiredOrgRoles {
+		for _, msp := range registry.mspIDs(role) {
+			if !seen[msp] {
+				seen[msp] = true
+				voters = append(voters, msp)
+			}
+		}
+	}
+	sort.Strings(voters)
+	return voters
+}
+
+// requireGovernanceVoter ensures the caller's org may vote and the caller is a supervisor
+func requireGovernanceVoter(ctx contractapi.TransactionContextInterface, voters []string) error {
+	if err := VerifyClientOrgMultiple(ctx, voters); err != nil {
+		return err
+	}
+	return RequireRole(ctx, RoleSupervisor)
+}
+
+// requiredApprovals returns how many of voterCount organizations make up the configured majority
+func requiredApprovals(voterCount int, approvalPercent int) int {
+	return (voterCount*approvalPercent + 99) / 100
+}
+
+// reportExpiry marks an open proposal past its deadline as EXPIRED (not persisted)
+func reportExpiry(proposal *GovernanceProposal, timestamp int64) {
+	if proposal.Status == ProposalOpen && timestamp > proposal.ExpiresAt {
+		proposal.Status = ProposalExpired
+	}
+}
+
+// checkProposalChanges validates a proposal's changes against the active configuration
+func checkProposalChanges(ctx contractapi.TransactionContextInterface, kind string, changesJson string) error {
+	switch kind {
+	case ProposalKindParams:
+		params, err := mergeParamsChanges(ctx, changesJson)
+		if err != nil {
+			return err
+		}
+		return params.validate()
+	case ProposalKindOrgRegistry:
+		registry, err := parseOrgRegistryChanges(changesJson)
+		if err != nil {
+			return err
+		}
+		return registry.validate()
+	}
+	return fmt.Errorf("invalid proposal kind %q: expected %s or %s", kind, ProposalKindParams, ProposalKindOrgRegistry)
+}
+
+// applyProposal writes an approved proposal's changes
+func applyProposal(ctx contractapi.TransactionContextInterface, proposal *GovernanceProposal, timestamp int64) error {
+	updatedBy := "governance:" + proposal.ProposalID
+
+	if proposal.Kind == ProposalKindOrgRegistry {
+		registry, err := parseOrgRegistryChanges(proposal.Changes)
+		if err != nil {
+			return err
+		}
+		registry.UpdatedAt = timestamp
+		registry.UpdatedBy = updatedBy
+		return putOrgRegistry(ctx, registry)
+	}
+
+	params, err := mergeParamsChanges(ctx, proposal.Changes)
+	if err != nil {
+		return err
+	}
+	params.UpdatedAt = timestamp
+	params.UpdatedBy = updatedBy
+	return putParams(ctx, params)
+}
+
+// mergeParamsChanges overlays a partial parameter set on the active parameters
+func mergeParamsChanges(ctx contractapi.TransactionContextInterface, changesJson string) (*ChainProofParams, error) {
+	params, err := getParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(changesJson))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(params); err != nil {
+		return nil, fmt.Errorf("failed to parse parameter changes: %v", err)
+	}
+	params.DocType = "params"
+	return params, nil
+}
+
+// parseOrgRegistryChanges parses a complete replacement organization registry
+func parseOrgRegistryChanges(changesJson string) (*OrgRegistry, error) {
+	decoder := json.NewDecoder(strings.NewReader(changesJson))
+	decoder.DisallowUnknownFields()
+
+	var registry OrgRegistry
+	if err := decoder.Decode(&registry); err != nil {
+		return nil, fmt.Errorf("failed to parse organization registry: %v", err)
+	}
+	registry.DocType = "org_registry"
+	return &registry, nil
+}
+
+// getGovernanceProposal reads a proposal
+func getGovernanceProposal(ctx contractapi.TransactionContextInterface, proposalId string) (*GovernanceProposal, error) {
+	key, err := ctx.GetStub().CreateCompositeKey(governanceProposalObjectType, []string{proposalId})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create governance proposal key: %v", err)
+	}
+	proposalJSON, err := readState(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read governance proposal: %v", err)
+	}
+	if proposalJSON == nil {
+		return nil, fmt.Errorf("governance proposal %s does not exist", proposalId)
+	}
+
+	var proposal GovernanceProposal
+	if err := json.Unmarshal(proposalJSON, &proposal); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal governance proposal: %v", err)
+	}
+	return &proposal, nil
+}
+
+// putGovernanceProposal stores a proposal
+func putGovernanceProposal(ctx contractapi.TransactionContextInterface, proposal *GovernanceProposal) error {
+	key, err := ctx.GetStub().CreateCompositeKey(governanceProposalObjectType, []string{proposal.ProposalID})
+	if err != nil {
+		return fmt.Errorf("failed to create governance proposal key: %v", err)
+	}
+	proposalJSON, err := json.Marshal(proposal)
+	if err != nil {
+		return fmt.Errorf("failed to marshal governance proposal: %v", err)
+	}
+	return writeState(ctx, key, proposalJSON)
+}
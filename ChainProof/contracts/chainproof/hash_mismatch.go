@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// =============================================================================
+// ChainProof - Legal-Stage Hash Mismatch
+// =============================================================================
+// When a legal reviewer re-downloads a file and its hash no longer matches, the
+// evidence moves to REVERIFY_REQUIRED and a new attestation round opens for
+// VerifierOrg. Export is blocked until re-verification settles it: a match
+// returns the evidence to VERIFIED for a fresh review, a confirmed mismatch
+// rejects it as if verification had failed in the first place.
+// =============================================================================
+
+// ReportHashMismatch records a hash mismatch found during legal review and sends the evidence back for re-verification
+func (c *LegalContract) ReportHashMismatch(
+	ctx contractapi.TransactionContextInterface,
+	evidenceId string,
+	computedHash string,
+	comment string,
+) error {
+	if err := RequireLegalOrg(ctx, RoleReviewer, RoleSupervisor); err != nil {
+		return err
+	}
+
+	evidence, err := getEvidence(ctx, evidenceId)
+	if err != nil {
+		return err
+	}
+
+	computedHash = strings.TrimSpace(computedHash)
+	if computedHash == "" {
+		return fmt.Errorf("computedHash is required")
+	}
+	if strings.EqualFold(computedHash, evidence.FileHash) {
+		return fmt.Errorf("computed hash matches stored hash %s; there is no mismatch to report", evidence.FileHash)
+	}
+
+	previousStatus := evidence.Status
+	if _, err := applyTransition(ctx, evidence, TransitionHashMismatch); err != nil {
+		return err
+	}
+
+	callerOrg, _ := GetClientOrgID(ctx)
+	reporter, err := GetClientFingerprint(ctx)
+	if err != nil {
+		return err
+	}
+	timestamp, err := getTxTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The review was based on a file whose integrity is now in question
+	description := fmt.Sprintf("Hash mismatch reported during legal review (was %s): computed=%s, stored=%s",
+		previousStatus, computedHash, evidence.FileHash)
+	if evidence.Verdict != "" {
+		reversed, err := reverseVerdict(ctx, evidence, "hash mismatch reported", timestamp)
+		if err != nil {
+			return err
+		}
+		description += fmt.Sprintf(" | Verdict reversed (trust score %+d)", reversed)
+	}
+	if comment != "" {
+		description += fmt.Sprintf(" | Comment: %s", comment)
+	}
+
+	evidence.IntegrityStatus = IntegrityPending
+	evidence.ReverifyStatus = ReverifyPending
+	evidence.MismatchReportedHash = computedHash
+	evidence.MismatchReportedAt = timestamp
+	evidence.MismatchReportedBy = reporter
+	evidence.VerificationRound++
+	evidence.VerificationDueAt = 0 // The earlier verification deadline does not apply to re-verification
+
+	if err := putEvidence(ctx, evidence); err != nil {
+		return err
+	}
+	if err := appendCustodyLog(ctx, evidenceId, ActionHashMismatch, description, timestamp); err != nil {
+		return err
+	}
+
+	if err := sendNotification(ctx, evidence.PublicKeyHash, evidenceId, NotifyHashFailure,
+		fmt.Sprintf("A hash mismatch was found for evidence (ID: %s) during legal review. It has been sent back for re-verification.", evidenceId),
+		callerOrg, timestamp); err != nil {
+		return err
+	}
+
+	return notifyOrgRole(ctx, OrgRoleVerifier, evidenceId, NotifyHashFailure,
+		fmt.Sprintf("Legal review computed hash %s for evidence %s (stored %s). Re-verification required in round %d.",
+			computedHash, evidenceId, evidence.FileHash, evidence.VerificationRound),
+		callerOrg, timestamp)
+}
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	"github.com/hyperledger/fabric-protos-go/peer"
+)
+
+// =============================================================================
+// ChainProof - Test Helpers
+// =============================================================================
+// shimtest.MockStub keeps world state but has no key history and no fixed
+// transaction time. ledgerStub adds both so history-based checks (custody
+// chain verification) run against what Fabric would return, and supports
+// partial composite key reads of private data and CouchDB rich queries (the
+// selector operators query_builder.go emits), which MockStub leaves
+// unimplemented. testIdentity stands in for the caller's certificate.
+// =============================================================================
+
+// ledgerVersion is one write of a key as GetHistoryForKey reports it
+type ledgerVersion struct {
+	txID      string
+	timestamp int64
+	value     []byte
+	isDelete  bool
+}
+
+// ledgerStub is a MockStub that records key history and uses a fixed transaction time
+type ledgerStub struct {
+	*shimtest.MockStub
+	now     int64
+	history map[string][]ledgerVersion
+}
+
+// newLedgerStub returns an empty ledger
+func newLedgerStub() *ledgerStub {
+	return &ledgerStub{
+		MockStub: shimtest.NewMockStub("chainproof", nil),
+		now:      1700000000,
+		history:  map[string][]ledgerVersion{},
+	}
+}
+
+// begin starts a new transaction one second after the previous one
+func (s *ledgerStub) begin(txID string) {
+	s.TxID = txID
+	s.now++
+}
+
+func (s *ledgerStub) GetTxTimestamp() (*timestamp.Timestamp, error) {
+	return &timestamp.Timestamp{Seconds: s.now}, nil
+}
+
+func (s *ledgerStub) SetEvent(name string, payload []byte) error {
+	return nil
+}
+
+func (s *ledgerStub) PutState(key string, value []byte) error {
+	s.history[key] = append(s.history[key], ledgerVersion{txID: s.TxID, timestamp: s.now, value: value})
+	return s.MockStub.PutState(key, value)
+}
+
+func (s *ledgerStub) DelState(key string) error {
+	s.history[key] = append(s.history[key], ledgerVersion{txID: s.TxID, timestamp: s.now, isDelete: true})
+	return s.MockStub.DelState(key)
+}
+
+// GetHistoryForKey returns the key's versions newest first, as Fabric does
+func (s *ledgerStub) GetHistoryForKey(key string) (shim.HistoryQueryIteratorInterface, error) {
+	versions := s.history[key]
+	newestFirst := make([]ledgerVersion, len(versions))
+	for i, version := range versions {
+		newestFirst[len(versions)-1-i] = version
+	}
+	return &ledgerHistory{versions: newestFirst}, nil
+}
+
+// ledgerHistory iterates over recorded versions
+type ledgerHistory struct {
+	versions []ledgerVersion
+	next     int
+}
+
+func (h *ledgerHistory) HasNext() bool {
+	return h.next < len(h.versions)
+}
+
+func (h *ledgerHistory) Next() (*queryresult.KeyModification, error) {
+	version := h.versions[h.next]
+	h.next++
+	return &queryresult.KeyModification{
+		TxId:      version.txID,
+		Value:     version.value,
+		Timestamp: &timestamp.Timestamp{Seconds: version.timestamp},
+		IsDelete:  version.isDelete,
+	}, nil
+}
+
+func (h *ledgerHistory) Close() error {
+	return nil
+}
+
+// GetPrivateDataByPartialCompositeKey returns a collection's matching keys in key order
+func (s *ledgerStub) GetPrivateDataByPartialCompositeKey(collection, objectType string, attributes []string) (shim.StateQueryIteratorInterface, error) {
+	prefix, err := s.CreateCompositeKey(objectType, attributes)
+	if err != nil {
+		return nil, err
+	}
+	keys := []string{}
+	for key := range s.PvtState[collection] {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	results := &ledgerRange{}
+	for _, key := range keys {
+		results.values = append(results.values, &queryresult.KV{Key: key, Value: s.PvtState[collection][key]})
+	}
+	return results, nil
+}
+
+// GetQueryResultWithPagination evaluates a Mango selector over world state and returns every match in one page
+func (s *ledgerStub) GetQueryResultWithPagination(query string, pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+	var mango struct {
+		Selector map[string]interface{} `json:"selector"`
+	}
+	if err := json.Unmarshal([]byte(query), &mango); err != nil {
+		return nil, nil, err
+	}
+
+	keys := []string{}
+	for key := range s.State {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	results := &ledgerRange{}
+	for _, key := range keys {
+		var document map[string]interface{}
+		if json.Unmarshal(s.State[key], &document) != nil {
+			continue
+		}
+		if selectorMatches(mango.Selector, document) {
+			results.values = append(results.values, &queryresult.KV{Key: key, Value: s.State[key]})
+		}
+	}
+	return results, &peer.QueryResponseMetadata{FetchedRecordsCount: int32(len(results.values))}, nil
+}
+
+// selectorMatches reports whether a document satisfies a Mango selector.
+// Like CouchDB, a condition on a missing field fails unless it is {"$exists": false}.
+func selectorMatches(selector map[string]interface{}, document map[string]interface{}) bool {
+	for field, condition := range selector {
+		if field == "$or" {
+			matched := false
+			for _, alternative := range condition.([]interface{}) {
+				if selectorMatches(alternative.(map[string]interface{}), document) {
+					matched = true
+				}
+			}
+			if !matched {
+				return false
+			}
+			continue
+		}
+
+		value, present := document[field]
+		operators, isOperator := condition.(map[string]interface{})
+		if !isOperator {
+			if !present || fmt.Sprint(value) != fmt.Sprint(condition) {
+				return false
+			}
+			continue
+		}
+		for operator, argument := range operators {
+			if operator == "$exists" {
+				if present != argument.(bool) {
+					return false
+				}
+				continue
+			}
+			if !present {
+				return false
+			}
+			switch operator {
+			case "$in":
+				found := false
+				for _, candidate := range argument.([]interface{}) {
+					if fmt.Sprint(value) == fmt.Sprint(candidate) {
+						found = true
+					}
+				}
+				if !found {
+					return false
+				}
+			case "$gte":
+				if value.(float64) < argument.(float64) {
+					return false
+				}
+			case "$lte":
+				if value.(float64) > argument.(float64) {
+					return false
+				}
+			default:
+				panic("unsupported selector operator " + operator)
+			}
+		}
+	}
+	return true
+}
+
+// ledgerRange iterates over the results of a range read
+type ledgerRange struct {
+	values []*queryresult.KV
+	next   int
+}
+
+func (r *ledgerRange) HasNext() bool {
+	return r.next < len(r.values)
+}
+
+func (r *ledgerRange) Next() (*queryresult.KV, error) {
+	value := r.values[r.next]
+	r.next++
+	return value, nil
+}
+
+func (r *ledgerRange) Close() error {
+	return nil
+}
+
+// testIdentity is a caller with a fixed MSP, ID and certificate attributes
+type testIdentity struct {
+	mspID string
+	id    string
+	attrs map[string]string
+}
+
+func (i *testIdentity) GetID() (string, error) {
+	return i.id, nil
+}
+
+func (i *testIdentity) GetMSPID() (string, error) {
+	return i.mspID, nil
+}
+
+func (i *testIdentity) GetAttributeValue(attrName string) (string, bool, error) {
+	value, found := i.attrs[attrName]
+	return value, found, nil
+}
+
+func (i *testIdentity) AssertAttributeValue(attrName, attrValue string) error {
+	if value, found := i.attrs[attrName]; !found || value != attrValue {
+		return fmt.Errorf("attribute %s is not %s", attrName, attrValue)
+	}
+	return nil
+}
+
+func (i *testIdentity) GetX509Certificate() (*x509.Certificate, error) {
+	return nil, nil
+}
+
+// newTestContext returns a fresh transaction context for caller on stub
+func newTestContext(stub shim.ChaincodeStubInterface, caller *testIdentity) *ChainProofContext {
+	ctx := new(ChainProofContext)
+	ctx.SetStub(stub)
+	ctx.SetClientIdentity(caller)
+	return ctx
+}
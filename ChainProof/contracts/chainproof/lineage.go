@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// =============================================================================
+// ChainProof - Evidence Version Chains
+// =============================================================================
+// A resubmission can name the evidence it supersedes. Each record links to
+// its predecessor (supersedesEvidenceId) and successor (supersededBy), so the
+// whole chain can be walked from any version.
+// =============================================================================
+
+// maxLineageLength bounds lineage walks
+const maxLineageLength = 100
+
+// supersedeEvidence marks previousId SUPERSEDED by next and links next to it
+// The previous version must belong to the same publicKeyHash.
+func supersedeEvidence(
+	ctx contractapi.TransactionContextInterface,
+	previousId string,
+	next *Evidence,
+	timestamp int64,
+) error {
+	if previousId == next.EvidenceID {
+		return fmt.Errorf("evidence %s cannot supersede itself", previousId)
+	}
+
+	previous, err := getEvidence(ctx, previousId)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(previous.PublicKeyHash, next.PublicKeyHash) {
+		return fmt.Errorf("evidence %s was not submitted with this key", previousId)
+	}
+
+	if _, err := applyTransition(ctx, previous, TransitionSupersede); err != nil {
+		return err
+	}
+	previous.SupersededBy = next.EvidenceID
+	previous.SupersededAt = timestamp
+
+	if err := putEvidence(ctx, previous); err != nil {
+		return err
+	}
+	if err := appendCustodyLog(ctx, previousId, ActionSupersede,
+		fmt.Sprintf("Superseded by newer version %s", next.EvidenceID), timestamp); err != nil {
+		return err
+	}
+
+	next.SupersedesEvidenceID = previousId
+	return nil
+}
+
+// loadEvidenceLineage walks the version chain containing evidenceId, oldest version first
+func loadEvidenceLineage(
+	ctx contractapi.TransactionContextInterface,
+	evidenceId string,
+) (*EvidenceLineage, error) {
+	evidence, err := getEvidence(ctx, evidenceId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Walk back to the first version
+	root := evidence
+	for steps := 0; root.SupersedesEvidenceID != ""; steps++ {
+		if steps >= maxLineageLength {
+			return nil, fmt.Errorf("lineage of %s is longer than %d versions", evidenceId, maxLineageLength)
+		}
+		if root, err = getEvidence(ctx, root.SupersedesEvidenceID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Walk forward to the current version
+	versions := []LineageVersion{}
+	for current := root; ; {
+		versions = append(versions, LineageVersion{
+			Version:              len(versions) + 1,
+			EvidenceID:           current.EvidenceID,
+			IPFSCID:              current.IPFSCID,
+			FileHash:             current.FileHash,
+			Status:               current.Status,
+			SubmittedAt:          current.SubmittedAt,
+			SupersedesEvidenceID: current.SupersedesEvidenceID,
+			SupersededBy:         current.SupersededBy,
+			SupersededAt:         current.SupersededAt,
+		})
+		if current.SupersededBy == "" {
+			break
+		}
+		if len(versions) >= maxLineageLength {
+			return nil, fmt.Errorf("lineage of %s is longer than %d versions", evidenceId, maxLineageLength)
+		}
+		if current, err = getEvidence(ctx, current.SupersededBy); err != nil {
+			return nil, err
+		}
+	}
+
+	return &EvidenceLineage{
+		EvidenceID:        evidenceId,
+		RootEvidenceID:    root.EvidenceID,
+		CurrentEvidenceID: versions[len(versions)-1].EvidenceID,
+		Versions:          versions,
+	}, nil
+}
@@ -8,12 +8,26 @@ import (
 )
 
 func main() {
-	// Create chaincode with all 4 contracts
+	whistleblowerContract := &WhistleblowerContract{} // Evidence submission (WhistleblowersOrg only)
+	verifierContract := &VerifierContract{}           // Integrity verification (VerifierOrg only)
+	legalContract := &LegalContract{}                 // Legal review & export (LegalOrg only)
+	queryContract := &QueryContract{}                 // Read operations (Any Org)
+	governanceContract := &GovernanceContract{}       // Parameter and registry proposals (multi-org vote)
+
+	// Every contract runs with ChainProofContext so transactions can read their own writes
+	whistleblowerContract.TransactionContextHandler = new(ChainProofContext)
+	verifierContract.TransactionContextHandler = new(ChainProofContext)
+	legalContract.TransactionContextHandler = new(ChainProofContext)
+	queryContract.TransactionContextHandler = new(ChainProofContext)
+	governanceContract.TransactionContextHandler = new(ChainProofContext)
+
+	// Create chaincode with all 5 contracts
 	chainproofChaincode, err := contractapi.NewChaincode(
-		&WhistleblowerContract{},  // Evidence submission (WhistleblowersOrg only)
-		&VerifierContract{},       // Integrity verification (VerifierOrg only)
-		&LegalContract{},          // Legal review & export (LegalOrg only)
-		&QueryContract{},          // Read operations (Any Org)
+		whistleblowerContract,
+		verifierContract,
+		legalContract,
+		queryContract,
+		governanceContract,
 	)
 
 	if err != nil {
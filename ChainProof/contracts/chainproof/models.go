@@ -21,30 +21,71 @@ type Evidence struct {
 	Status          string       `json:"status"`          // Current workflow status
 	PolygonTxHash   string       `json:"polygonTxHash"`   // Public blockchain anchor (optional)
 	PolygonAnchorAt int64        `json:"polygonAnchorAt"` // When anchored to Polygon
-	IntegrityStatus string       `json:"integrityStatus"` // PENDING, VERIFIED, FAILED
+	IntegrityStatus string       `json:"integrityStatus"` // PENDING, VERIFIED, FAILED, DISPUTED
 	VerifiedAt      int64        `json:"verifiedAt"`      // When integrity was verified
 	ReviewedAt      int64        `json:"reviewedAt"`      // When legal review completed
 	ExportedAt      int64        `json:"exportedAt"`      // When exported for court
-	CustodyLog      []CustodyLog `json:"custodyLog"`      // Chain of custody entries
+	ExportCount     int          `json:"exportCount"`     // Number of stored export records (latest sequence)
+	ExportRequestID string       `json:"exportRequestId"` // Open export request awaiting a second approver
+	CustodyLog      []CustodyLog `json:"custodyLog,omitempty"` // Assembled from custody records, never stored inline
 	// Bulk submission support
 	BulkSubmissionID string `json:"bulkSubmissionId"` // Groups evidence from same bulk upload
 	BulkIndex        int    `json:"bulkIndex"`        // Index within bulk submission
 	// Pseudonymous identity support
 	PublicKeyHash string `json:"publicKeyHash"` // SHA256 hash of submitter's public key (anonymous identifier)
+	PublicKey     string `json:"publicKey"`     // Submitter's public key (PEM or base64 DER SPKI)
 	Signature     string `json:"signature"`     // Digital signature of evidence hash using private key
 	// Rejection info
-	RejectionComment string `json:"rejectionComment"` // Comment when integrity fails
+	RejectionComment         string `json:"rejectionComment"`         // Comment when integrity fails
+	RejectionReputationDelta int    `json:"rejectionReputationDelta"` // Trust score change actually applied for the rejection
+	// Multi-verifier quorum
+	VerificationRound int `json:"verificationRound"` // Current attestation round (advances after a dispute or appeal)
+	// Appeal of a rejection
+	AppealStatus string `json:"appealStatus"` // "", PENDING, UPHELD, DENIED
+	AppealReason string `json:"appealReason"` // Reason signed by the key holder
+	AppealedAt   int64  `json:"appealedAt"`   // When the appeal was filed
+	AppealRound  int    `json:"appealRound"`  // First attestation round of the appeal (0 = never appealed)
+	// Withdrawal by the submitter
+	WithdrawnAt      int64  `json:"withdrawnAt"`      // When the submitter withdrew the evidence
+	WithdrawalReason string `json:"withdrawalReason"` // Optional reason signed by the key holder
+	// Version chain
+	SupersedesEvidenceID string `json:"supersedesEvidenceId"` // Previous version of this evidence
+	SupersededBy         string `json:"supersededBy"`         // Next version, set when this record is superseded
+	SupersededAt         int64  `json:"supersededAt"`         // When this record was superseded
+	// Assignment and SLA tracking
+	VerificationAssignee   string `json:"verificationAssignee"`   // Fingerprint of the responsible verifier
+	VerificationAssignedAt int64  `json:"verificationAssignedAt"` // When the verifier was assigned
+	VerificationDueAt      int64  `json:"verificationDueAt"`      // Verification due date (0 = unassigned)
+	ReviewAssignee         string `json:"reviewAssignee"`         // Fingerprint of the responsible legal reviewer
+	ReviewAssignedAt       int64  `json:"reviewAssignedAt"`       // When the reviewer was assigned
+	ReviewDueAt            int64  `json:"reviewDueAt"`            // Review due date (0 = unassigned)
+	// Legal review outcome
+	Verdict                string `json:"verdict"`                // SUBSTANTIATED, UNSUBSTANTIATED, INCONCLUSIVE, OUT_OF_SCOPE
+	VerdictReputationDelta int    `json:"verdictReputationDelta"` // Trust score change actually applied for the verdict
+	JustificationHash      string `json:"justificationHash"`      // Salted SHA256 of the private justification
+	ReviewReopenCount      int    `json:"reviewReopenCount"`      // Times a completed review was reopened
+	ReviewReopenedAt       int64  `json:"reviewReopenedAt"`       // When the review was last reopened
+	// Legal-stage hash mismatch
+	ReverifyStatus       string `json:"reverifyStatus"`       // "", PENDING, CONFIRMED, FAILED
+	MismatchReportedHash string `json:"mismatchReportedHash"` // Hash the legal reviewer computed
+	MismatchReportedAt   int64  `json:"mismatchReportedAt"`   // When the mismatch was reported
+	MismatchReportedBy   string `json:"mismatchReportedBy"`   // Fingerprint of the reporting legal identity
 }
 
+
 // Evidence Status Constants
 const (
-	StatusSubmitted       = "SUBMITTED"        // Initial state after submission
-	StatusVerified        = "VERIFIED"         // Integrity check passed
-	StatusIntegrityFailed = "INTEGRITY_FAILED" // Integrity check failed (deprecated, use REJECTED)
-	StatusRejected        = "REJECTED"         // Integrity check failed, cannot proceed to legal
-	StatusUnderReview     = "UNDER_REVIEW"     // Legal team reviewing
-	StatusReviewed        = "REVIEWED"         // Legal review complete
-	StatusExported        = "EXPORTED"         // Exported for court proceedings
+	StatusSubmitted   = "SUBMITTED"         // Initial state after submission
+	StatusVerified    = "VERIFIED"          // Integrity check passed
+	StatusRejected    = "REJECTED"          // Integrity check failed, cannot proceed to legal
+	StatusDisputed    = "DISPUTED"          // Verifiers disagreed, a new attestation round is open
+	StatusAppealed    = "APPEALED"          // Rejection appealed, awaiting re-verification by different verifiers
+	StatusWithdrawn   = "WITHDRAWN"         // Retracted by the submitter, workflow ended
+	StatusSuperseded  = "SUPERSEDED"        // Replaced by a newer version, workflow ended
+	StatusReverify    = "REVERIFY_REQUIRED" // Legal team reported a hash mismatch, awaiting re-verification
+	StatusUnderReview = "UNDER_REVIEW"      // Legal team reviewing
+	StatusReviewed    = "REVIEWED"          // Legal review complete
+	StatusExported    = "EXPORTED"          // Exported for court proceedings
 )
 
 // Integrity Status Constants
@@ -52,6 +93,29 @@ const (
 	IntegrityPending  = "PENDING"  // Not yet verified
 	IntegrityVerified = "VERIFIED" // Hash matches
 	IntegrityFailed   = "FAILED"   // Hash mismatch
+	IntegrityDisputed = "DISPUTED" // Verifiers disagreed
+)
+
+// Appeal Status Constants
+const (
+	AppealPending = "PENDING" // Awaiting re-verification
+	AppealUpheld  = "UPHELD"  // Re-verification passed, rejection overturned
+	AppealDenied  = "DENIED"  // Re-verification failed, rejection stands
+)
+
+// Re-verification Status Constants (legal-stage hash mismatch)
+const (
+	ReverifyPending   = "PENDING"   // Awaiting re-verification by VerifierOrg
+	ReverifyConfirmed = "CONFIRMED" // Re-verification matched the stored hash
+	ReverifyFailed    = "FAILED"    // Re-verification confirmed the mismatch
+)
+
+// Legal Verdict Constants
+const (
+	VerdictSubstantiated   = "SUBSTANTIATED"   // Evidence supports the allegation
+	VerdictUnsubstantiated = "UNSUBSTANTIATED" // Evidence does not support the allegation
+	VerdictInconclusive    = "INCONCLUSIVE"    // Evidence is insufficient to decide
+	VerdictOutOfScope      = "OUT_OF_SCOPE"    // Allegation is outside the legal team's remit
 )
 
 // Category Constants (optional field)
@@ -75,26 +139,190 @@ const (
 )
 
 // CustodyLog represents a single custody chain entry (public ledger)
+// Stored under custody~evidenceId~sequence and never rewritten
 type CustodyLog struct {
-	Action      string `json:"action"`      // What happened (SUBMIT, VERIFY, REVIEW, etc.)
-	ActorOrg    string `json:"actorOrg"`    // Organization MSP ID (not individual identity)
-	Timestamp   int64  `json:"timestamp"`   // When the action occurred
-	Description string `json:"description"` // Human-readable description
+	DocType          string `json:"docType"`          // "custody_entry"
+	EvidenceID       string `json:"evidenceId"`       // Evidence this entry belongs to
+	Sequence         int    `json:"sequence"`         // Position in the evidence's custody chain
+	PrevHash         string `json:"prevHash"`         // EntryHash of the previous entry (empty for the first)
+	EntryHash        string `json:"entryHash"`        // SHA256 of this entry's content, including PrevHash
+	Action           string `json:"action"`           // What happened (SUBMIT, VERIFY, REVIEW, etc.)
+	ActorOrg         string `json:"actorOrg"`         // Organization MSP ID (not individual identity)
+	ActorFingerprint string `json:"actorFingerprint"` // SHA256 of the creator identity ID
+	TxID             string `json:"txId"`             // Ledger transaction that produced this entry
+	Timestamp        int64  `json:"timestamp"`        // Transaction proposal timestamp
+	Description      string `json:"description"`      // Human-readable description
+
+	MigratedTxID string `json:"migratedTxId,omitempty"` // Transaction that copied a legacy embedded entry into the chain
 }
 
 // Custody Action Constants
 const (
-	ActionSubmit       = "SUBMIT"
-	ActionBulkSubmit   = "BULK_SUBMIT"
-	ActionVerify       = "VERIFY"
-	ActionReview       = "REVIEW"
-	ActionExport       = "EXPORT"
-	ActionAnchor       = "ANCHOR"
-	ActionAddNote      = "ADD_NOTE"
-	ActionAddComment   = "ADD_COMMENT"
-	ActionStatusChange = "STATUS_CHANGE"
+	ActionSubmit        = "SUBMIT"
+	ActionBulkSubmit    = "BULK_SUBMIT"
+	ActionAttest        = "ATTEST"
+	ActionVerify        = "VERIFY"
+	ActionAppeal        = "APPEAL"
+	ActionWithdraw      = "WITHDRAW"
+	ActionSupersede     = "SUPERSEDE"
+	ActionCaseLink      = "CASE_LINK"
+	ActionCaseUnlink    = "CASE_UNLINK"
+	ActionCaseReassign  = "CASE_REASSIGN"
+	ActionCaseClose     = "CASE_CLOSE"
+	ActionAssign        = "ASSIGN"
+	ActionReview        = "REVIEW"
+	ActionReopenReview  = "REOPEN_REVIEW"
+	ActionHashMismatch  = "HASH_MISMATCH"
+	ActionExportRequest = "EXPORT_REQUEST"
+	ActionExport        = "EXPORT"
+	ActionAnchor        = "ANCHOR"
+	ActionAddNote       = "ADD_NOTE"
+	ActionAddComment    = "ADD_COMMENT"
+	ActionStatusChange  = "STATUS_CHANGE"
+)
+
+// VerificationAttestation is one verifier identity's hash check of an evidence item (public ledger)
+// Stored under attestation~evidenceId~round~fingerprint
+type VerificationAttestation struct {
+	DocType             string `json:"docType"`             // "attestation"
+	EvidenceID          string `json:"evidenceId"`          // Evidence that was checked
+	Round               int    `json:"round"`               // Attestation round the check belongs to
+	VerifierFingerprint string `json:"verifierFingerprint"` // SHA256 of the verifier's identity ID
+	VerifierOrg         string `json:"verifierOrg"`         // Verifier MSP ID
+	ComputedHash        string `json:"computedHash"`        // Hash the verifier computed from the file
+	Passed              bool   `json:"passed"`              // Whether the computed hash matched
+	Comment             string `json:"comment"`             // Rejection reason for failed checks
+	TxID                string `json:"txId"`                // Transaction that recorded the attestation
+	Timestamp           int64  `json:"timestamp"`           // Transaction proposal timestamp
+}
+
+// LineageVersion summarizes one version in an evidence version chain
+type LineageVersion struct {
+	Version              int    `json:"version"`              // 1 for the first submission
+	EvidenceID           string `json:"evidenceId"`           // Evidence record of this version
+	IPFSCID              string `json:"ipfsCid"`              // IPFS Content Identifier
+	FileHash             string `json:"fileHash"`             // SHA256 hash of the file
+	Status               string `json:"status"`               // Current workflow status
+	SubmittedAt          int64  `json:"submittedAt"`          // When this version was submitted
+	SupersedesEvidenceID string `json:"supersedesEvidenceId"` // Previous version
+	SupersededBy         string `json:"supersededBy"`         // Next version
+	SupersededAt         int64  `json:"supersededAt"`         // When the next version replaced it
+}
+
+// EvidenceLineage is the full version chain containing an evidence item
+type EvidenceLineage struct {
+	EvidenceID        string           `json:"evidenceId"`        // Version the lineage was requested for
+	RootEvidenceID    string           `json:"rootEvidenceId"`    // First version
+	CurrentEvidenceID string           `json:"currentEvidenceId"` // Latest version
+	Versions          []LineageVersion `json:"versions"`          // Oldest first
+}
+
+// =============================================================================
+// Case Management Models
+// =============================================================================
+
+// Case groups evidence into a legal investigation (public ledger)
+// Stored under case~caseId; case_evidence~evidenceId~caseId indexes the reverse link
+type Case struct {
+	DocType      string   `json:"docType"`      // "case"
+	CaseID       string   `json:"caseId"`       // Unique identifier
+	Title        string   `json:"title"`        // Short investigation title
+	Status       string   `json:"status"`       // OPEN or CLOSED
+	Jurisdiction string   `json:"jurisdiction"` // Court or authority the case is prepared for
+	EvidenceIDs  []string `json:"evidenceIds"`  // Linked evidence, in link order
+	AssignedTo   string   `json:"assignedTo"`   // Fingerprint of the responsible legal reviewer
+	OpenedBy     string   `json:"openedBy"`     // Fingerprint of the identity that opened the case
+	OpenedAt     int64    `json:"openedAt"`     // When the case was opened
+	UpdatedAt    int64    `json:"updatedAt"`    // When the case last changed
+	ClosedAt     int64    `json:"closedAt"`     // When the case was closed
+	Resolution   string   `json:"resolution"`   // Closing summary
+}
+
+// Case Status Constants
+const (
+	CaseStatusOpen   = "OPEN"   // Investigation in progress
+	CaseStatusClosed = "CLOSED" // Investigation finished, no further changes
 )
 
+// CaseQueryResult holds case query results with pagination metadata
+type CaseQueryResult struct {
+	Records             []*Case `json:"records"`
+	FetchedRecordsCount int     `json:"fetchedRecordsCount"`
+	Bookmark            string  `json:"bookmark"` // For pagination
+}
+
+// =============================================================================
+// SLA Tracking Models
+// =============================================================================
+
+// SLA stages
+const (
+	SLAStageVerification = "VERIFICATION" // SUBMITTED/DISPUTED/APPEALED, measured from SubmittedAt
+	SLAStageReview       = "REVIEW"       // VERIFIED/UNDER_REVIEW, measured from VerifiedAt
+)
+
+// OverdueEvidence describes one evidence item past its deadline for a stage
+type OverdueEvidence struct {
+	EvidenceID     string `json:"evidenceId"`
+	Status         string `json:"status"`
+	Stage          string `json:"stage"`
+	StageStartedAt int64  `json:"stageStartedAt"` // SubmittedAt, MismatchReportedAt or VerifiedAt
+	SLADueAt       int64  `json:"slaDueAt"`       // StageStartedAt + stage SLA
+	AssignedDueAt  int64  `json:"assignedDueAt"`  // Due date set on assignment (0 = unassigned)
+	Assignee       string `json:"assignee"`       // Responsible identity fingerprint, if assigned
+	OverdueSeconds int64  `json:"overdueSeconds"` // Time past the deadline (AssignedDueAt if set, else SLADueAt)
+}
+
+// OverdueEvidenceQueryResult holds a page of overdue evidence
+type OverdueEvidenceQueryResult struct {
+	Stage               string             `json:"stage"`
+	CheckedAt           int64              `json:"checkedAt"`
+	Records             []*OverdueEvidence `json:"records"`
+	FetchedRecordsCount int                `json:"fetchedRecordsCount"`
+	Bookmark            string             `json:"bookmark"` // For pagination
+}
+
+// Verification queue priority factors
+const (
+	QueueFactorTrust      = "TRUST"      // Submitter's current trust score
+	QueueFactorSeverity   = "SEVERITY"   // Severity of the evidence category
+	QueueFactorAge        = "AGE"        // Share of the verification deadline already used
+	QueueFactorAssignment = "ASSIGNMENT" // Whether the item is assigned to the caller, nobody or someone else
+)
+
+// QueuePriorityFactor explains one component of a verification queue priority
+type QueuePriorityFactor struct {
+	Factor string `json:"factor"` // QueueFactor*
+	Score  int    `json:"score"`  // Component score (0-100)
+	Weight int    `json:"weight"` // Share of the priority, in percent
+	Points int    `json:"points"` // Score * Weight / 100, added to the priority
+	Reason string `json:"reason"` // Human readable explanation
+}
+
+// VerificationQueueItem is one pending evidence item in the verification queue
+// The submitter's publicKeyHash is deliberately left out.
+type VerificationQueueItem struct {
+	EvidenceID        string                 `json:"evidenceId"`
+	Status            string                 `json:"status"`
+	Category          string                 `json:"category"`
+	FileType          string                 `json:"fileType"`
+	SubmittedAt       int64                  `json:"submittedAt"`
+	VerificationRound int                    `json:"verificationRound"`
+	Assignee          string                 `json:"assignee"`          // Responsible verifier fingerprint, if assigned
+	VerificationDueAt int64                  `json:"verificationDueAt"` // Assigned due date (0 = unassigned)
+	Priority          int                    `json:"priority"`          // Sum of factor points (0-100), highest first
+	Factors           []*QueuePriorityFactor `json:"factors"`
+}
+
+// VerificationQueueResult holds a page of the verification queue
+type VerificationQueueResult struct {
+	CheckedAt           int64                    `json:"checkedAt"`
+	TotalPending        int                      `json:"totalPending"` // Items awaiting verification across all pages
+	Records             []*VerificationQueueItem `json:"records"`
+	FetchedRecordsCount int                      `json:"fetchedRecordsCount"`
+	Bookmark            string                   `json:"bookmark"` // Offset of the next page ("" when done)
+}
+
 // =============================================================================
 // Bulk Submission Models
 // =============================================================================
@@ -162,23 +390,137 @@ type EvidenceQueryResult struct {
 	Bookmark            string      `json:"bookmark"` // For pagination
 }
 
+// CustodyLogQueryResult holds one page of custody entries
+type CustodyLogQueryResult struct {
+	EvidenceID          string       `json:"evidenceId"`
+	Entries             []CustodyLog `json:"entries"`
+	FetchedRecordsCount int          `json:"fetchedRecordsCount"`
+	Bookmark            string       `json:"bookmark"` // For pagination
+}
+
+// CustodyDivergence names the first custody entry that failed verification
+type CustodyDivergence struct {
+	Sequence int    `json:"sequence"` // Entry sequence (-1 when the divergence is not tied to one entry)
+	TxID     string `json:"txId"`     // Transaction involved in the divergence
+	Reason   string `json:"reason"`   // What did not match
+}
+
+// CustodyChainReport is the pass/fail result of VerifyCustodyChain
+type CustodyChainReport struct {
+	EvidenceID      string             `json:"evidenceId"`
+	Valid           bool               `json:"valid"`
+	EntryCount      int                `json:"entryCount"`
+	LegacyEntries   int                `json:"legacyEntries"`   // Entries from the pre-chain embedded log (hash-linked only since migration)
+	HeadHash        string             `json:"headHash"`        // EntryHash of the last verified entry
+	CheckedAt       int64              `json:"checkedAt"`       // Transaction timestamp of the check
+	FirstDivergence *CustodyDivergence `json:"firstDivergence"` // nil when the chain is intact
+}
+
 // ExportRecord represents a court-ready export package
 type ExportRecord struct {
-	EvidenceID      string       `json:"evidenceId"`
-	IPFSCID         string       `json:"ipfsCid"`
-	FileHash        string       `json:"fileHash"`
-	FileType        string       `json:"fileType"`
-	Category        string       `json:"category"`
-	SubmittedAt     int64        `json:"submittedAt"`
-	VerifiedAt      int64        `json:"verifiedAt"`
-	ReviewedAt      int64        `json:"reviewedAt"`
-	ExportedAt      int64        `json:"exportedAt"`
-	PolygonTxHash   string       `json:"polygonTxHash"`
-	IntegrityStatus string       `json:"integrityStatus"`
-	CustodyLog      []CustodyLog `json:"custodyLog"`
-	ExportHash      string       `json:"exportHash"` // Hash of this export record
+	EvidenceID      string           `json:"evidenceId"`
+	IPFSCID         string           `json:"ipfsCid"`
+	FileHash        string           `json:"fileHash"`
+	FileType        string           `json:"fileType"`
+	Category        string           `json:"category"`
+	SubmittedAt     int64            `json:"submittedAt"`
+	VerifiedAt      int64            `json:"verifiedAt"`
+	ReviewedAt      int64            `json:"reviewedAt"`
+	ExportedAt      int64            `json:"exportedAt"`
+	PolygonTxHash   string           `json:"polygonTxHash"`
+	IntegrityStatus string           `json:"integrityStatus"`
+	Verdict         string           `json:"verdict"`
+	CustodyLog      []CustodyLog     `json:"custodyLog"`
+	Lineage         []LineageVersion `json:"lineage"`        // Every version of the evidence, oldest first
+	ExportSequence  int              `json:"exportSequence"` // 1 for the first export, incremented on every re-export
+	ExportedBy      string           `json:"exportedBy"`     // MSP ID of the exporting organization
+	RequestID       string           `json:"requestId"`      // Export request this record was issued for
+	RequestedBy     string           `json:"requestedBy"`    // Fingerprint of the identity that requested the export
+	ApprovedBy      string           `json:"approvedBy"`     // Fingerprint of the distinct identity that approved it
+	TxID            string           `json:"txId"`           // Transaction that issued the export
+	ExportHash      string           `json:"exportHash"`     // SHA256 of this record with exportHash empty
+}
+
+// ExportRequest is a pending or settled four-eyes request to export evidence
+type ExportRequest struct {
+	DocType        string `json:"docType"`        // "export_request"
+	RequestID      string `json:"requestId"`      // Transaction ID of the request
+	EvidenceID     string `json:"evidenceId"`     // Evidence to export
+	Status         string `json:"status"`         // PENDING, APPROVED, EXPIRED
+	Reason         string `json:"reason"`         // Why the export is needed
+	RequestedBy    string `json:"requestedBy"`    // Fingerprint of the requesting LegalOrg identity
+	RequestedAt    int64  `json:"requestedAt"`    // When the export was requested
+	ExpiresAt      int64  `json:"expiresAt"`      // Approval deadline
+	ReviewedAt     int64  `json:"reviewedAt"`     // Review the request was based on
+	ApprovedBy     string `json:"approvedBy"`     // Fingerprint of the approving LegalOrg identity
+	ApprovedAt     int64  `json:"approvedAt"`     // When the export was approved
+	ExportSequence int    `json:"exportSequence"` // Export record issued on approval
+	ExportHash     string `json:"exportHash"`     // Hash of the issued export record
 }
 
+// Export Request Status Constants
+const (
+	ExportRequestPending  = "PENDING"  // Awaiting approval by a second identity
+	ExportRequestApproved = "APPROVED" // Approved and export record issued
+	ExportRequestExpired  = "EXPIRED"  // Approval window passed
+)
+
+// ExportVerificationReport is the result of checking a handed-over export against the ledger
+type ExportVerificationReport struct {
+	EvidenceID             string   `json:"evidenceId"`
+	ExportSequence         int      `json:"exportSequence"`
+	Valid                  bool     `json:"valid"`                  // All checks below passed
+	HashValid              bool     `json:"hashValid"`              // exportHash matches the record's content
+	MatchesStoredRecord    bool     `json:"matchesStoredRecord"`    // Identical to the export stored on the ledger
+	MatchesCurrentEvidence bool     `json:"matchesCurrentEvidence"` // Evidence is still exported with the same file and custody chain
+	IsLatestExport         bool     `json:"isLatestExport"`         // No later export has been issued
+	Discrepancies          []string `json:"discrepancies"`          // Why any check failed
+	CheckedAt              int64    `json:"checkedAt"`              // Transaction timestamp of the check
+}
+
+// ReviewJustification is the reasoning behind a legal verdict (LegalPrivateCollection)
+type ReviewJustification struct {
+	DocType          string `json:"docType"`          // "review_justification"
+	EvidenceID       string `json:"evidenceId"`       // Reference to evidence
+	Verdict          string `json:"verdict"`          // Verdict the justification supports
+	Justification    string `json:"justification"`    // Reviewer's reasoning
+	Salt             string `json:"salt"`             // Hex salt; the public hash is SHA256(salt||justification)
+	ReviewerID       string `json:"reviewerId"`       // Fingerprint of the reviewing identity
+	LegalReviewerOrg string `json:"legalReviewerOrg"` // Organization that completed the review
+	TxID             string `json:"txId"`             // Transaction that completed the review
+	CreatedAt        int64  `json:"createdAt"`        // When the review completed
+}
+
+// ReputationChange records one trust score change and its cause (WhistleblowerPrivateCollection)
+type ReputationChange struct {
+	DocType       string `json:"docType"`       // "reputation_change"
+	PublicKeyHash string `json:"publicKeyHash"` // Anonymous identifier
+	EvidenceID    string `json:"evidenceId"`    // Evidence that caused the change
+	Cause         string `json:"cause"`         // ReputationCause*
+	Detail        string `json:"detail"`        // Human readable explanation
+	Weight        int    `json:"weight"`        // Event weight booked by the scoring model
+	Decay         int    `json:"decay"`         // Score drift since the previous change (DECAY model)
+	Delta         int    `json:"delta"`         // Total trust score change, including decay
+	TrustScore    int    `json:"trustScore"`    // Trust score after the change
+	Model         string `json:"model"`         // Scoring model that computed the score
+	TxID          string `json:"txId"`          // Transaction that made the change
+	Timestamp     int64  `json:"timestamp"`     // When the change was made
+}
+
+// Reputation Change Cause Constants
+const (
+	ReputationCauseSubmitted            = "SUBMITTED"             // Key submitted evidence (counted, no weight)
+	ReputationCauseVerified             = "VERIFIED"              // Evidence passed verification
+	ReputationCauseRejected             = "REJECTED"              // Evidence failed verification
+	ReputationCauseRejectionReversed    = "REJECTION_REVERSED"    // Rejection overturned on appeal
+	ReputationCauseVerificationReversed = "VERIFICATION_REVERSED" // Verification revoked after a hash mismatch
+	ReputationCauseWithdrawn            = "WITHDRAWN"             // Submitter retracted the evidence (no weight)
+	ReputationCauseExported             = "EXPORTED"              // Evidence first exported for court (no weight)
+	ReputationCauseRecomputed           = "RECOMPUTED"            // Record corrected by RecomputeReputation
+	ReputationCauseLegalVerdict         = "LEGAL_VERDICT"         // Verdict effect applied when a legal review completes
+	ReputationCauseVerdictReversed      = "VERDICT_REVERSED"      // Verdict effect undone when a review is reopened
+)
+
 // HistoryEntry represents a single ledger history entry
 type HistoryEntry struct {
 	TxId      string    `json:"txId"`
@@ -216,11 +558,41 @@ const (
 	NotifyRejection     = "REJECTION"      // Evidence rejected due to integrity failure
 	NotifyHashFailure   = "HASH_FAILURE"   // Hash mismatch detected during legal review
 	NotifyVerified      = "VERIFIED"       // Evidence successfully verified
+	NotifyAppealUpheld  = "APPEAL_UPHELD"  // Appeal succeeded, rejection overturned
+	NotifyAppealDenied  = "APPEAL_DENIED"  // Appeal failed, rejection stands
+	NotifyWithdrawn     = "WITHDRAWN"      // Evidence withdrawn by its submitter (org notification)
 	NotifyReviewed      = "REVIEWED"       // Legal review completed
 	NotifyExported      = "EXPORTED"       // Evidence exported for court
 	NotifyComment       = "COMMENT"        // Comment added by legal team
 )
 
+// OrgNotification is a message addressed to a whole organization (public ledger)
+// Stored under org_notification~orgMSP~timestamp~evidenceId~txId
+type OrgNotification struct {
+	DocType        string `json:"docType"`        // "org_notification"
+	NotificationID string `json:"notificationId"` // Composite key of the record
+	OrgMSP         string `json:"orgMsp"`         // Recipient organization
+	EvidenceID     string `json:"evidenceId"`     // Related evidence
+	MessageType    string `json:"messageType"`    // Type of notification
+	Message        string `json:"message"`        // Human-readable message
+	FromOrg        string `json:"fromOrg"`        // Organization sending the notification
+	TxID           string `json:"txId"`           // Transaction that raised it
+	Timestamp      int64  `json:"timestamp"`      // When notification was created
+}
+
+// Org notification key layout
+const (
+	orgNotificationObjectType = "org_notification"
+	orgNotificationTimeFormat = "%012d" // Zero-padded so keys sort chronologically
+)
+
+// OrgNotificationQueryResult holds a page of org notifications
+type OrgNotificationQueryResult struct {
+	Notifications       []*OrgNotification `json:"notifications"`
+	FetchedRecordsCount int                `json:"fetchedRecordsCount"`
+	Bookmark            string             `json:"bookmark"`
+}
+
 // =============================================================================
 // Pseudonymous Reputation Models
 // =============================================================================
@@ -232,15 +604,103 @@ type Reputation struct {
 	TotalSubmissions       int    `json:"totalSubmissions"`       // Number of submissions
 	VerifiedSubmissions    int    `json:"verifiedSubmissions"`    // Submissions that passed verification
 	RejectedSubmissions    int    `json:"rejectedSubmissions"`    // Submissions that failed verification
+	WithdrawnSubmissions   int    `json:"withdrawnSubmissions"`   // Submissions retracted by the submitter (not rejections)
 	ExportedSubmissions    int    `json:"exportedSubmissions"`    // Submissions that reached court export
-	TrustScore             int    `json:"trustScore"`             // Calculated trust score (0-100)
+	TrustScore             int    `json:"trustScore"`             // Calculated trust score (0-100) as of LastUpdatedAt
+	PositiveWeight         int    `json:"positiveWeight"`         // Total weight of favourable events
+	NegativeWeight         int    `json:"negativeWeight"`         // Total weight of unfavourable events
+	ScoringModel           string `json:"scoringModel"`           // Model that last computed TrustScore
 	FirstSubmissionAt      int64  `json:"firstSubmissionAt"`      // Timestamp of first submission
 	LastSubmissionAt       int64  `json:"lastSubmissionAt"`       // Timestamp of last submission
 	LastUpdatedAt          int64  `json:"lastUpdatedAt"`          // When reputation was last updated
 }
 
+// ReputationDifference is one field where the stored and recomputed reputation disagree
+type ReputationDifference struct {
+	Field      string `json:"field"`      // JSON name of the Reputation field
+	Stored     int64  `json:"stored"`     // Value in the stored record
+	Recomputed int64  `json:"recomputed"` // Value rebuilt from the public ledger
+}
+
+// ReputationRecomputation compares a key's stored reputation with one rebuilt from the public ledger
+type ReputationRecomputation struct {
+	PublicKeyHash string                  `json:"publicKeyHash"` // Anonymous identifier
+	EvidenceCount int                     `json:"evidenceCount"` // Evidence records replayed
+	EventCount    int                     `json:"eventCount"`    // Reputation events derived from their history
+	Stored        *Reputation             `json:"stored"`        // Stored record (nil if none)
+	Recomputed    *Reputation             `json:"recomputed"`    // Record rebuilt under the active parameters
+	Differences   []*ReputationDifference `json:"differences"`   // Empty when the records agree
+	Corrected     bool                    `json:"corrected"`     // Recomputed record was written back
+	CheckedAt     int64                   `json:"checkedAt"`     // When the recomputation ran
+}
+
+// ReputationRecomputationPage holds one page of a batch recomputation
+type ReputationRecomputationPage struct {
+	Results             []*ReputationRecomputation `json:"results"`
+	FetchedRecordsCount int                        `json:"fetchedRecordsCount"` // Evidence records scanned
+	Bookmark            string                     `json:"bookmark"`
+}
+
 // NotificationQueryResult holds notification query results
 type NotificationQueryResult struct {
 	Notifications []*Notification `json:"notifications"`
 	Count         int             `json:"count"`
 }
+
+// =============================================================================
+// Governance Models
+// =============================================================================
+
+// GovernanceProposal is a proposed change to the ledger parameters or organization registry
+type GovernanceProposal struct {
+	DocType           string   `json:"docType"`           // "governance_proposal"
+	ProposalID        string   `json:"proposalId"`        // Transaction ID of the proposal
+	Kind              string   `json:"kind"`              // PARAMS or ORG_REGISTRY
+	Changes           string   `json:"changes"`           // JSON: partial parameter set, or the complete registry
+	Description       string   `json:"description"`       // Why the change is proposed
+	ProposedBy        string   `json:"proposedBy"`        // MSP ID of the proposing organization
+	ProposerID        string   `json:"proposerId"`        // Fingerprint of the proposing identity
+	Voters            []string `json:"voters"`            // MSP IDs entitled to vote, fixed when proposed
+	RequiredApprovals int      `json:"requiredApprovals"` // Approvals needed for the change to take effect
+	Approvals         int      `json:"approvals"`         // Approving votes so far
+	Rejections        int      `json:"rejections"`        // Rejecting votes so far
+	Status            string   `json:"status"`            // OPEN, APPLIED, REJECTED, FAILED, EXPIRED
+	Outcome           string   `json:"outcome"`           // How the proposal was decided
+	CreatedAt         int64    `json:"createdAt"`         // When the proposal was made
+	ExpiresAt         int64    `json:"expiresAt"`         // Voting deadline
+	DecidedAt         int64    `json:"decidedAt"`         // When the proposal was applied, rejected or failed
+	DecidedTxID       string   `json:"decidedTxId"`       // Transaction that decided the proposal
+}
+
+// GovernanceVote is one organization's vote on a proposal
+type GovernanceVote struct {
+	DocType    string `json:"docType"`    // "governance_vote"
+	ProposalID string `json:"proposalId"` // Proposal voted on
+	VoterMSP   string `json:"voterMsp"`   // Voting organization
+	VoterID    string `json:"voterId"`    // Fingerprint of the voting identity
+	Approve    bool   `json:"approve"`    // true to approve, false to reject
+	Comment    string `json:"comment"`    // Optional reasoning
+	TxID       string `json:"txId"`       // Transaction that cast the vote
+	VotedAt    int64  `json:"votedAt"`    // When the vote was cast
+}
+
+// Governance Proposal Kind Constants
+const (
+	ProposalKindParams      = "PARAMS"       // Merge a partial parameter set into the active parameters
+	ProposalKindOrgRegistry = "ORG_REGISTRY" // Replace the organization registry
+)
+
+// Governance Proposal Status Constants
+const (
+	ProposalOpen     = "OPEN"     // Collecting votes
+	ProposalApplied  = "APPLIED"  // Approved by the required majority and in effect
+	ProposalRejected = "REJECTED" // Can no longer reach the required majority
+	ProposalFailed   = "FAILED"   // Approved, but no longer valid against the active configuration
+	ProposalExpired  = "EXPIRED"  // Voting window passed without a decision
+)
+
+// GovernanceProposalDetail is a proposal together with its votes
+type GovernanceProposalDetail struct {
+	Proposal *GovernanceProposal `json:"proposal"`
+	Votes    []*GovernanceVote   `json:"votes"`
+}
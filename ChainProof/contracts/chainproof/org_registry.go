@@ -0,0 +1,250 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// =============================================================================
+// ChainProof - Organization Registry
+// =============================================================================
+// Which MSPs act as submitters, verifiers, legal reviewers or auditors, and
+// which private collection each role writes to, is read from a registry record
+// on the public ledger instead of compile-time constants. InitLedger writes the
+// defaults (the three Microfab orgs); after that the registry only changes
+// through multi-org governance. A missing record falls back to the defaults.
+// Renaming a collection here must be matched by the chaincode definition's
+// collection config, which can be updated without redeploying code.
+// =============================================================================
+
+// orgRegistryKey is the world state key of the organization registry
+const orgRegistryKey = "config_org_registry"
+
+// Organization roles in the registry
+const (
+	OrgRoleSubmitter = "submitter" // Relays whistleblower submissions; owns notifications and reputation
+	OrgRoleVerifier  = "verifier"  // Attests to evidence integrity
+	OrgRoleLegal     = "legal"     // Reviews and exports evidence
+	OrgRoleAuditor   = "auditor"   // Read-only access to public queries
+)
+
+// requiredOrgRoles must each have at least one MSP and a collection
+var requiredOrgRoles = []string{OrgRoleSubmitter, OrgRoleVerifier, OrgRoleLegal}
+
+// OrgRoleEntry lists the MSPs holding an organization role and the role's private collection
+type OrgRoleEntry struct {
+	MSPIDs     []string `json:"mspIds"`     // Member MSP IDs
+	Collection string   `json:"collection"` // Private data collection for the role ("" for none)
+}
+
+// OrgRegistry maps organization roles to MSP IDs and private collections
+type OrgRegistry struct {
+	DocType   string                   `json:"docType"`   // "org_registry"
+	Roles     map[string]*OrgRoleEntry `json:"roles"`     // Keyed by OrgRole*
+	UpdatedAt int64                    `json:"updatedAt"` // When the registry last changed
+	UpdatedBy string                   `json:"updatedBy"` // MSP ID (or governance proposal) that last changed it
+}
+
+// defaultOrgRegistry returns the built-in three-organization registry
+func defaultOrgRegistry() *OrgRegistry {
+	return &OrgRegistry{
+		DocType: "org_registry",
+		Roles: map[string]*OrgRoleEntry{
+			OrgRoleSubmitter: {MSPIDs: []string{WhistleblowersOrgMSP}, Collection: WhistleblowerPrivateCollection},
+			OrgRoleVerifier:  {MSPIDs: []string{VerifierOrgMSP}, Collection: VerifierPrivateCollection},
+			OrgRoleLegal:     {MSPIDs: []string{LegalOrgMSP}, Collection: LegalPrivateCollection},
+			OrgRoleAuditor:   {MSPIDs: []string{}, Collection: ""},
+		},
+	}
+}
+
+// validate checks that every role is known and every required role is populated
+func (r *OrgRegistry) validate() error {
+	for role, entry := range r.Roles {
+		if !isOrgRole(role) {
+			return fmt.Errorf("unknown organization role %q", role)
+		}
+		if entry == nil {
+			return fmt.Errorf("organization role %s has no entry", role)
+		}
+		seen := map[string]bool{}
+		for _, msp := range entry.MSPIDs {
+			if strings.TrimSpace(msp) == "" {
+				return fmt.Errorf("organization role %s lists an empty MSP ID", role)
+			}
+			if seen[msp] {
+				return fmt.Errorf("organization role %s lists MSP %s twice", role, msp)
+			}
+			seen[msp] = true
+		}
+	}
+	for _, role := range requiredOrgRoles {
+		entry := r.Roles[role]
+		if entry == nil || len(entry.MSPIDs) == 0 {
+			return fmt.Errorf("organization role %s must have at least one MSP", role)
+		}
+		if entry.Collection == "" {
+			return fmt.Errorf("organization role %s must name a private collection", role)
+		}
+	}
+	return nil
+}
+
+// mspIDs returns the MSPs holding an organization role
+func (r *OrgRegistry) mspIDs(role string) []string {
+	if entry := r.Roles[role]; entry != nil {
+		return entry.MSPIDs
+	}
+	return []string{}
+}
+
+// collection returns an organization role's private collection
+func (r *OrgRegistry) collection(role string) string {
+	if entry := r.Roles[role]; entry != nil {
+		return entry.Collection
+	}
+	return ""
+}
+
+// rolesOf returns the organization roles an MSP holds
+func (r *OrgRegistry) rolesOf(msp string) []string {
+	roles := []string{}
+	for role, entry := range r.Roles {
+		if entry == nil {
+			continue
+		}
+		for _, member := range entry.MSPIDs {
+			if member == msp {
+				roles = append(roles, role)
+				break
+			}
+		}
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// allMSPIDs returns every MSP holding any organization role
+func (r *OrgRegistry) allMSPIDs() []string {
+	seen := map[string]bool{}
+	all := []string{}
+	for _, entry := range r.Roles {
+		if entry == nil {
+			continue
+		}
+		for _, msp := range entry.MSPIDs {
+			if !seen[msp] {
+				seen[msp] = true
+				all = append(all, msp)
+			}
+		}
+	}
+	sort.Strings(all)
+	return all
+}
+
+// isOrgRole checks if role is one of the registry roles
+func isOrgRole(role string) bool {
+	switch role {
+	case OrgRoleSubmitter, OrgRoleVerifier, OrgRoleLegal, OrgRoleAuditor:
+		return true
+	}
+	return false
+}
+
+// GetOrgRegistry returns the active organization registry
+func (c *QueryContract) GetOrgRegistry(ctx contractapi.TransactionContextInterface) (*OrgRegistry, error) {
+	if err := RequireAnyOrg(ctx); err != nil {
+		return nil, err
+	}
+
+	return getOrgRegistry(ctx)
+}
+
+// getOrgRegistry reads the organization registry, falling back to defaults
+func getOrgRegistry(ctx contractapi.TransactionContextInterface) (*OrgRegistry, error) {
+	registryJSON, err := readState(ctx, orgRegistryKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read organization registry: %v", err)
+	}
+	if registryJSON == nil {
+		return defaultOrgRegistry(), nil
+	}
+
+	var registry OrgRegistry
+	if err := json.Unmarshal(registryJSON, &registry); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal organization registry: %v", err)
+	}
+	return &registry, nil
+}
+
+// putOrgRegistry validates and stores the organization registry
+func putOrgRegistry(ctx contractapi.TransactionContextInterface, registry *OrgRegistry) error {
+	if err := registry.validate(); err != nil {
+		return err
+	}
+
+	registryJSON, err := json.Marshal(registry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal organization registry: %v", err)
+	}
+	return writeState(ctx, orgRegistryKey, registryJSON)
+}
+
+// initOrgRegistry writes the default registry unless one is already stored
+func initOrgRegistry(ctx contractapi.TransactionContextInterface) error {
+	registryJSON, err := readState(ctx, orgRegistryKey)
+	if err != nil {
+		return fmt.Errorf("failed to read organization registry: %v", err)
+	}
+	if registryJSON != nil {
+		return nil
+	}
+
+	registry := defaultOrgRegistry()
+	registry.UpdatedAt, err = getTxTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+	registry.UpdatedBy, _ = GetClientOrgID(ctx)
+	return putOrgRegistry(ctx, registry)
+}
+
+// orgCollection returns the private collection of an organization role
+func orgCollection(ctx contractapi.TransactionContextInterface, role string) (string, error) {
+	registry, err := getOrgRegistry(ctx)
+	if err != nil {
+		return "", err
+	}
+	collection := registry.collection(role)
+	if collection == "" {
+		return "", fmt.Errorf("organization role %s has no private collection", role)
+	}
+	return collection, nil
+}
+
+// notifyOrgRole sends an org notification to every MSP holding an organization role
+func notifyOrgRole(
+	ctx contractapi.TransactionContextInterface,
+	role string,
+	evidenceId string,
+	messageType string,
+	message string,
+	fromOrg string,
+	timestamp int64,
+) error {
+	registry, err := getOrgRegistry(ctx)
+	if err != nil {
+		return err
+	}
+	for _, msp := range registry.mspIDs(role) {
+		if err := sendOrgNotification(ctx, msp, evidenceId, messageType, message, fromOrg, timestamp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// =============================================================================
+// ChainProof - Ledger Parameters
+// =============================================================================
+// Tunable workflow parameters stored on the public ledger so they can change
+// without redeploying chaincode. Missing records fall back to defaults.
+// Every parameter, from the verification quorum and export approval window to
+// verdict effects and the governance rules themselves, only changes through
+// GovernanceContract proposals; no single organization can rewrite them.
+// =============================================================================
+
+// paramsKey is the world state key of the active parameter set
+const paramsKey = "config_params"
+
+// Default parameter values
+const (
+	DefaultVerificationQuorum     = 1              // Independent verifier attestations required per round
+	DefaultVerificationSLASeconds = 3 * 24 * 3600  // Time allowed from submission to verification outcome
+	DefaultReviewSLASeconds       = 14 * 24 * 3600 // Time allowed from verification to completed legal review
+	DefaultExportApprovalSeconds  = 24 * 3600      // Time a second identity has to approve an export request
+
+	DefaultReputationVerifyReward    = 10                      // Trust score gained when evidence is verified
+	DefaultReputationRejectPenalty   = 15                      // Trust score lost when evidence is rejected
+	DefaultInitialTrustScore         = 50                      // Trust score of a key's first submission
+	DefaultReputationModel           = ReputationModelAdditive // Scoring model for trust scores
+	DefaultReputationHalfLifeSeconds = 180 * 24 * 3600         // Time for a DECAY score to move halfway back to the initial score
+	DefaultReputationPriorStrength   = 20                      // Pseudo-events behind the BAYESIAN prior
+	DefaultGovernanceApprovalPercent = 51                      // Share of voting organizations that must approve a proposal
+	DefaultGovernanceVotingSeconds   = 7 * 24 * 3600           // Time organizations have to vote on a proposal
+)
+
+// maxReputationWeight bounds the verification reward and rejection penalty
+const maxReputationWeight = 50
+
+// minReputationHalfLifeSeconds bounds the DECAY half-life from below
+const minReputationHalfLifeSeconds = 24 * 3600
+
+// maxVerdictReputationEffect bounds the trust score change a single verdict may apply
+const maxVerdictReputationEffect = 20
+
+// defaultVerdictReputationEffects returns the built-in trust score change per legal verdict
+func defaultVerdictReputationEffects() map[string]int {
+	return map[string]int{
+		VerdictSubstantiated:   3,
+		VerdictUnsubstantiated: -3,
+		VerdictInconclusive:    0,
+		VerdictOutOfScope:      0,
+	}
+}
+
+// ChainProofParams holds the active workflow parameters
+type ChainProofParams struct {
+	DocType                string `json:"docType"`                // "params"
+	VerificationQuorum     int    `json:"verificationQuorum"`     // Attestations required before a verification outcome
+	VerificationSLASeconds int64  `json:"verificationSlaSeconds"` // Verification deadline, measured from SubmittedAt or MismatchReportedAt
+	ReviewSLASeconds       int64  `json:"reviewSlaSeconds"`       // Legal review deadline, measured from VerifiedAt
+	// Trust score change applied when a legal review completes with each verdict
+	VerdictReputationEffects map[string]int `json:"verdictReputationEffects"`
+	ExportApprovalSeconds    int64          `json:"exportApprovalSeconds"` // Export requests expire after this window
+
+	ReputationVerifyReward    int      `json:"reputationVerifyReward"`    // Trust score gained when evidence is verified
+	ReputationRejectPenalty   int      `json:"reputationRejectPenalty"`   // Trust score lost when evidence is rejected
+	InitialTrustScore         int      `json:"initialTrustScore"`         // Trust score of a key's first submission
+	ReputationModel           string   `json:"reputationModel"`           // ADDITIVE, BAYESIAN or DECAY
+	ReputationHalfLifeSeconds int64    `json:"reputationHalfLifeSeconds"` // DECAY half-life towards the initial score
+	ReputationPriorStrength   int      `json:"reputationPriorStrength"`   // BAYESIAN prior weight, in events
+	AllowedCategories         []string `json:"allowedCategories"`         // Accepted evidence categories (an empty category is always accepted)
+	GovernanceApprovalPercent int      `json:"governanceApprovalPercent"` // Share of voting organizations that must approve a proposal
+	GovernanceVotingSeconds   int64    `json:"governanceVotingSeconds"`   // Proposals expire after this window
+
+	UpdatedAt int64  `json:"updatedAt"` // When the parameters last changed
+	UpdatedBy string `json:"updatedBy"` // MSP ID (or governance proposal) that last changed them
+}
+
+// defaultParams returns the built-in parameter set
+func defaultParams() *ChainProofParams {
+	return &ChainProofParams{
+		DocType:                "params",
+		VerificationQuorum:     DefaultVerificationQuorum,
+		VerificationSLASeconds: DefaultVerificationSLASeconds,
+		ReviewSLASeconds:       DefaultReviewSLASeconds,
+
+		VerdictReputationEffects: defaultVerdictReputationEffects(),
+		ExportApprovalSeconds:    DefaultExportApprovalSeconds,
+
+		ReputationVerifyReward:    DefaultReputationVerifyReward,
+		ReputationRejectPenalty:   DefaultReputationRejectPenalty,
+		InitialTrustScore:         DefaultInitialTrustScore,
+		ReputationModel:           DefaultReputationModel,
+		ReputationHalfLifeSeconds: DefaultReputationHalfLifeSeconds,
+		ReputationPriorStrength:   DefaultReputationPriorStrength,
+		AllowedCategories:         defaultAllowedCategories(),
+		GovernanceApprovalPercent: DefaultGovernanceApprovalPercent,
+		GovernanceVotingSeconds:   DefaultGovernanceVotingSeconds,
+	}
+}
+
+// defaultAllowedCategories returns the built-in evidence categories
+func defaultAllowedCategories() []string {
+	return []string{
+		CategoryFinancialFraud,
+		CategoryCorruption,
+		CategoryAbuse,
+		CategoryHarassment,
+		CategoryEnvironmental,
+		CategorySafety,
+		CategoryOther,
+	}
+}
+
+// validate checks parameter bounds
+func (p *ChainProofParams) validate() error {
+	if p.VerificationQuorum < 1 {
+		return fmt.Errorf("verificationQuorum must be at least 1, got %d", p.VerificationQuorum)
+	}
+	if p.VerificationSLASeconds < 1 || p.ReviewSLASeconds < 1 {
+		return fmt.Errorf("SLA durations must be positive, got verification=%d review=%d", p.VerificationSLASeconds, p.ReviewSLASeconds)
+	}
+	if p.ExportApprovalSeconds < 1 {
+		return fmt.Errorf("exportApprovalSeconds must be positive, got %d", p.ExportApprovalSeconds)
+	}
+	for verdict, effect := range p.VerdictReputationEffects {
+		if err := validateVerdict(verdict); err != nil {
+			return err
+		}
+		if effect < -maxVerdictReputationEffect || effect > maxVerdictReputationEffect {
+			return fmt.Errorf("reputation effect for %s must be between -%d and %d, got %d",
+				verdict, maxVerdictReputationEffect, maxVerdictReputationEffect, effect)
+		}
+	}
+	if p.ReputationVerifyReward < 0 || p.ReputationVerifyReward > maxReputationWeight ||
+		p.ReputationRejectPenalty < 0 || p.ReputationRejectPenalty > maxReputationWeight {
+		return fmt.Errorf("reputation weights must be between 0 and %d, got reward=%d penalty=%d",
+			maxReputationWeight, p.ReputationVerifyReward, p.ReputationRejectPenalty)
+	}
+	if p.InitialTrustScore < 0 || p.InitialTrustScore > 100 {
+		return fmt.Errorf("initialTrustScore must be between 0 and 100, got %d", p.InitialTrustScore)
+	}
+	if err := validateReputationModel(p.ReputationModel); err != nil {
+		return err
+	}
+	if p.ReputationHalfLifeSeconds < minReputationHalfLifeSeconds {
+		return fmt.Errorf("reputationHalfLifeSeconds must be at least %d, got %d",
+			minReputationHalfLifeSeconds, p.ReputationHalfLifeSeconds)
+	}
+	if p.ReputationPriorStrength < 1 {
+		return fmt.Errorf("reputationPriorStrength must be at least 1, got %d", p.ReputationPriorStrength)
+	}
+	if len(p.AllowedCategories) == 0 {
+		return fmt.Errorf("allowedCategories must list at least one category")
+	}
+	seen := map[string]bool{}
+	for _, category := range p.AllowedCategories {
+		if category == "" || seen[category] {
+			return fmt.Errorf("allowedCategories must be non-empty and unique, got %q", category)
+		}
+		seen[category] = true
+	}
+	if p.GovernanceApprovalPercent < 51 || p.GovernanceApprovalPercent > 100 {
+		return fmt.Errorf("governanceApprovalPercent must be between 51 and 100, got %d", p.GovernanceApprovalPercent)
+	}
+	if p.GovernanceVotingSeconds < 1 {
+		return fmt.Errorf("governanceVotingSeconds must be positive, got %d", p.GovernanceVotingSeconds)
+	}
+	return nil
+}
+
+// validateCategory rejects categories outside the allowed list (the category itself is optional)
+func validateCategory(params *ChainProofParams, category string) error {
+	if category == "" {
+		return nil
+	}
+	for _, allowed := range params.AllowedCategories {
+		if category == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid category %q: expected one of %s", category, strings.Join(params.AllowedCategories, ", "))
+}
+
+// getParams reads the active parameters, falling back to defaults
+func getParams(ctx contractapi.TransactionContextInterface) (*ChainProofParams, error) {
+	paramsJSON, err := readState(ctx, paramsKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read parameters: %v", err)
+	}
+	if paramsJSON == nil {
+		return defaultParams(), nil
+	}
+
+	params := defaultParams()
+	if err := json.Unmarshal(paramsJSON, params); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal parameters: %v", err)
+	}
+	return params, nil
+}
+
+// putParams validates and stores the parameter set
+func putParams(ctx contractapi.TransactionContextInterface, params *ChainProofParams) error {
+	if err := params.validate(); err != nil {
+		return err
+	}
+
+	paramsJSON, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("failed to marshal parameters: %v", err)
+	}
+	return writeState(ctx, paramsKey, paramsJSON)
+}
+
+// initParams writes the default parameters unless a parameter set is already stored
+func initParams(ctx contractapi.TransactionContextInterface) error {
+	paramsJSON, err := readState(ctx, paramsKey)
+	if err != nil {
+		return fmt.Errorf("failed to read parameters: %v", err)
+	}
+	if paramsJSON != nil {
+		return nil
+	}
+
+	params := defaultParams()
+	params.UpdatedAt, err = getTxTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+	params.UpdatedBy, _ = GetClientOrgID(ctx)
+	return putParams(ctx, params)
+}
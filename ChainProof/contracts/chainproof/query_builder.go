@@ -0,0 +1,169 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// =============================================================================
+// ChainProof - CouchDB Selector Builder
+// =============================================================================
+// Builds Mango queries with encoding/json instead of fmt.Sprintf, so caller
+// input can never add selector operators. Values must be plain scalars.
+// =============================================================================
+
+// MaxSelectorValueLength caps the size of a single selector value
+const MaxSelectorValueLength = 256
+
+// Selector builds a CouchDB Mango query for one docType
+type Selector struct {
+	fields map[string]interface{}
+	sort   []map[string]string
+	err    error
+}
+
+// mangoQuery is the JSON shape sent to CouchDB
+type mangoQuery struct {
+	Selector map[string]interface{} `json:"selector"`
+	Sort     []map[string]string    `json:"sort,omitempty"`
+}
+
+// NewSelector starts a selector matching documents of the given docType
+func NewSelector(docType string) *Selector {
+	s := &Selector{fields: map[string]interface{}{}}
+	return s.Equals("docType", docType)
+}
+
+// NewCondition starts a docType-free selector for use inside AnyOf
+func NewCondition() *Selector {
+	return &Selector{fields: map[string]interface{}{}}
+}
+
+// Equals matches field against a plain string value
+func (s *Selector) Equals(field string, value string) *Selector {
+	if !s.checkField(field) {
+		return s
+	}
+	if err := validatePlainValue(field, value); err != nil {
+		s.err = err
+		return s
+	}
+	s.fields[field] = value
+	return s
+}
+
+// In matches field against any of the given plain string values
+func (s *Selector) In(field string, values []string) *Selector {
+	if !s.checkField(field) {
+		return s
+	}
+	if len(values) == 0 {
+		s.err = fmt.Errorf("invalid query value for %s: at least one value is required", field)
+		return s
+	}
+	for _, value := range values {
+		if err := validatePlainValue(field, value); err != nil {
+			s.err = err
+			return s
+		}
+	}
+	s.fields[field] = map[string]interface{}{"$in": values}
+	return s
+}
+
+// Between matches numeric field values in the inclusive range [from, to]
+func (s *Selector) Between(field string, from int64, to int64) *Selector {
+	if !s.checkField(field) {
+		return s
+	}
+	if from > to {
+		s.err = fmt.Errorf("invalid query range for %s: %d is after %d", field, from, to)
+		return s
+	}
+	s.fields[field] = map[string]interface{}{"$gte": from, "$lte": to}
+	return s
+}
+
+// Absent matches documents that do not have field at all
+func (s *Selector) Absent(field string) *Selector {
+	if !s.checkField(field) {
+		return s
+	}
+	s.fields[field] = map[string]interface{}{"$exists": false}
+	return s
+}
+
+// AnyOf matches documents satisfying at least one of the given conditions
+func (s *Selector) AnyOf(conditions ...*Selector) *Selector {
+	if s.err != nil {
+		return s
+	}
+	if len(conditions) == 0 {
+		s.err = fmt.Errorf("invalid query: AnyOf needs at least one condition")
+		return s
+	}
+	alternatives := []map[string]interface{}{}
+	for _, condition := range conditions {
+		if condition.err != nil {
+			s.err = condition.err
+			return s
+		}
+		alternatives = append(alternatives, condition.fields)
+	}
+	s.fields["$or"] = alternatives
+	return s
+}
+
+// SortDesc orders results by field, newest/highest first
+func (s *Selector) SortDesc(field string) *Selector {
+	if s.checkField(field) {
+		s.sort = append(s.sort, map[string]string{field: "desc"})
+	}
+	return s
+}
+
+// Build returns the encoded query, or the first validation error
+func (s *Selector) Build() (string, error) {
+	if s.err != nil {
+		return "", s.err
+	}
+
+	queryJSON, err := json.Marshal(mangoQuery{Selector: s.fields, Sort: s.sort})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode query: %v", err)
+	}
+	return string(queryJSON), nil
+}
+
+// checkField rejects field names that are not simple identifiers (field names come from code)
+func (s *Selector) checkField(field string) bool {
+	if s.err != nil {
+		return false
+	}
+	if field == "" || strings.HasPrefix(field, "$") || strings.ContainsAny(field, "\"\\{}[]") {
+		s.err = fmt.Errorf("invalid query field %q", field)
+		return false
+	}
+	return true
+}
+
+// validatePlainValue rejects values that look like selector syntax rather than data
+func validatePlainValue(field string, value string) error {
+	if value == "" {
+		return fmt.Errorf("invalid query value for %s: value is required", field)
+	}
+	if len(value) > MaxSelectorValueLength {
+		return fmt.Errorf("invalid query value for %s: longer than %d characters", field, MaxSelectorValueLength)
+	}
+	if strings.HasPrefix(value, "$") || strings.ContainsAny(value, "\"\\{}[]") {
+		return fmt.Errorf("invalid query value for %s: must be a plain value", field)
+	}
+	for _, r := range value {
+		if unicode.IsControl(r) {
+			return fmt.Errorf("invalid query value for %s: contains control characters", field)
+		}
+	}
+	return nil
+}
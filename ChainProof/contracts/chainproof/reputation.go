@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// =============================================================================
+// ChainProof - Reputation History
+// =============================================================================
+// Fabric keeps no history for private data, so each trust score change is
+// appended as its own ReputationChange record under
+// reputation_history~publicKeyHash~timestamp~txId~sequence~cause in
+// WhistleblowerPrivateCollection. The sequence numbers the changes within one
+// transaction (a bulk submission changes the score once per item), so none
+// overwrites another. Only the key holder can read the trail.
+// =============================================================================
+
+// reputationHistoryObjectType is the composite key object type for reputation changes
+const reputationHistoryObjectType = "reputation_history"
+
+// reputationHistoryTimeFormat zero-pads timestamps so the history sorts chronologically
+const reputationHistoryTimeFormat = "%012d"
+
+// reputationHistorySequenceFormat zero-pads the in-transaction sequence so changes sort in the order applied
+const reputationHistorySequenceFormat = "%06d"
+
+// GetReputationHistory returns the trust score changes for the caller's key, oldest first
+// The caller proves key ownership by signing BuildKeyChallenge(GET_REPUTATION_HISTORY, publicKeyHash, "", challengeTimestamp).
+func (c *WhistleblowerContract) GetReputationHistory(
+	ctx contractapi.TransactionContextInterface,
+	publicKey string,
+	challengeTimestamp int64,
+	signature string,
+) ([]*ReputationChange, error) {
+	if err := RequireWhistleblowerOrg(ctx); err != nil {
+		return nil, err
+	}
+
+	publicKeyHash, err := verifyKeyOwnership(ctx, publicKey, ChallengeGetReputationHistory, "", challengeTimestamp, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	collection, err := orgCollection(ctx, OrgRoleSubmitter)
+	if err != nil {
+		return nil, err
+	}
+	resultsIterator, err := ctx.GetStub().GetPrivateDataByPartialCompositeKey(collection,
+		reputationHistoryObjectType, []string{publicKeyHash})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reputation history: %v", err)
+	}
+	defer resultsIterator.Close()
+
+	changes := []*ReputationChange{}
+	for resultsIterator.HasNext() {
+		queryResult, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		var change ReputationChange
+		if err := json.Unmarshal(queryResult.Value, &change); err != nil {
+			return nil, err
+		}
+		changes = append(changes, &change)
+	}
+
+	return changes, nil
+}
+
+// recordReputationChange appends a trust score change to the key's reputation history
+func recordReputationChange(
+	ctx contractapi.TransactionContextInterface,
+	publicKeyHash string,
+	change *ReputationChange,
+	timestamp int64,
+) error {
+	txId := ctx.GetStub().GetTxID()
+	key, err := ctx.GetStub().CreateCompositeKey(reputationHistoryObjectType,
+		[]string{publicKeyHash, fmt.Sprintf(reputationHistoryTimeFormat, timestamp), txId,
+			fmt.Sprintf(reputationHistorySequenceFormat, nextReputationChangeSequence(ctx)), change.Cause})
+	if err != nil {
+		return fmt.Errorf("failed to create reputation history key: %v", err)
+	}
+
+	change.DocType = "reputation_change"
+	change.PublicKeyHash = publicKeyHash
+	change.TxID = txId
+	change.Timestamp = timestamp
+	changeJSON, err := json.Marshal(change)
+	if err != nil {
+		return fmt.Errorf("failed to marshal reputation change: %v", err)
+	}
+
+	collection, err := orgCollection(ctx, OrgRoleSubmitter)
+	if err != nil {
+		return err
+	}
+	return writePrivateData(ctx, collection, key, changeJSON)
+}
@@ -0,0 +1,338 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// =============================================================================
+// ChainProof - Reputation Engine
+// =============================================================================
+// Every reputation update goes through applyReputationEvent: it loads the
+// key's record, lets the scoring model configured in the ledger parameters
+// compute the new TrustScore, updates the counters and appends a history
+// record naming the cause. Each event carries its weight under the additive
+// rule (reward, penalty or verdict effect); the models differ in how weights
+// become a score:
+//   ADDITIVE  score += weight, clamped to 0-100 (the original rule)
+//   BAYESIAN  mean of a beta distribution: positive and negative weight are
+//             added to a prior of ReputationPriorStrength centred on
+//             InitialTrustScore
+//   DECAY     additive, but between events the score relaxes towards
+//             InitialTrustScore with a half-life of ReputationHalfLifeSeconds
+// Scores use integer arithmetic only so every endorsing peer computes the same
+// value regardless of platform.
+// =============================================================================
+
+// Reputation scoring models
+const (
+	ReputationModelAdditive = "ADDITIVE"
+	ReputationModelBayesian = "BAYESIAN"
+	ReputationModelDecay    = "DECAY"
+)
+
+// maxTrustScore is the upper bound of every scoring model
+const maxTrustScore = 100
+
+// ppm is the fixed-point scale of decay factors (parts per million)
+const ppm = 1000000
+
+// halfLifeSteps holds 2^(-k/8) in parts per million for k = 0..8
+var halfLifeSteps = [9]int64{1000000, 917004, 840896, 771105, 707107, 648420, 594604, 545254, 500000}
+
+// reputationEvent is one occurrence that may move a key's reputation
+type reputationEvent struct {
+	EvidenceID string // Evidence that caused the event
+	Cause      string // ReputationCause*
+	Detail     string // Human readable explanation
+	Weight     int    // Signed score change under the additive rule
+	Reversal   bool   // Weight undoes an earlier event rather than adding new evidence
+}
+
+// reputationScorer turns reputation events into a trust score
+type reputationScorer interface {
+	// current returns the score as of now, before any new event
+	current(reputation *Reputation, params *ChainProofParams, now int64) int
+	// apply books the event on reputation, sets its TrustScore and returns the weight booked
+	apply(reputation *Reputation, event reputationEvent, params *ChainProofParams) int
+}
+
+// reputationScorers maps each model to its implementation
+var reputationScorers = map[string]reputationScorer{
+	ReputationModelAdditive: additiveScorer{},
+	ReputationModelBayesian: bayesianScorer{},
+	ReputationModelDecay:    decayScorer{},
+}
+
+// additiveScorer adds weights to the score directly
+type additiveScorer struct{}
+
+func (additiveScorer) current(reputation *Reputation, params *ChainProofParams, now int64) int {
+	return reputation.TrustScore
+}
+
+func (additiveScorer) apply(reputation *Reputation, event reputationEvent, params *ChainProofParams) int {
+	previous := reputation.TrustScore
+	reputation.TrustScore = clampTrustScore(previous + event.Weight)
+	booked := reputation.TrustScore - previous
+	bookReputationWeight(reputation, booked, event.Reversal)
+	return booked
+}
+
+// bayesianScorer reports the mean of a beta distribution over positive and negative weight
+type bayesianScorer struct{}
+
+func (bayesianScorer) current(reputation *Reputation, params *ChainProofParams, now int64) int {
+	return betaMeanScore(reputation, params)
+}
+
+func (bayesianScorer) apply(reputation *Reputation, event reputationEvent, params *ChainProofParams) int {
+	bookReputationWeight(reputation, event.Weight, event.Reversal)
+	reputation.TrustScore = betaMeanScore(reputation, params)
+	return event.Weight
+}
+
+// decayScorer relaxes the score towards the initial score between events
+type decayScorer struct{}
+
+func (decayScorer) current(reputation *Reputation, params *ChainProofParams, now int64) int {
+	if reputation.LastUpdatedAt == 0 {
+		return reputation.TrustScore
+	}
+	factor := halfLifeFactor(now-reputation.LastUpdatedAt, params.ReputationHalfLifeSeconds)
+	offset := int64(reputation.TrustScore - params.InitialTrustScore)
+	return params.InitialTrustScore + int(offset*factor/ppm)
+}
+
+func (decayScorer) apply(reputation *Reputation, event reputationEvent, params *ChainProofParams) int {
+	return additiveScorer{}.apply(reputation, event, params)
+}
+
+// getReputationScorer returns the scorer for the configured model
+func getReputationScorer(params *ChainProofParams) (reputationScorer, error) {
+	scorer, ok := reputationScorers[params.ReputationModel]
+	if !ok {
+		return nil, fmt.Errorf("unknown reputation model %q", params.ReputationModel)
+	}
+	return scorer, nil
+}
+
+// validateReputationModel rejects models without a scorer
+func validateReputationModel(model string) error {
+	if _, ok := reputationScorers[model]; !ok {
+		return fmt.Errorf("invalid reputationModel %q: expected %s, %s or %s",
+			model, ReputationModelAdditive, ReputationModelBayesian, ReputationModelDecay)
+	}
+	return nil
+}
+
+// applyReputationEvent updates a key's reputation for one event, records it in the
+// reputation history and returns the weight booked (what a later reversal must undo)
+func applyReputationEvent(
+	ctx contractapi.TransactionContextInterface,
+	publicKeyHash string,
+	event reputationEvent,
+	timestamp int64,
+) (int, error) {
+	if publicKeyHash == "" {
+		return 0, nil // Legacy evidence without publicKeyHash
+	}
+
+	params, err := getParams(ctx)
+	if err != nil {
+		return 0, err
+	}
+	scorer, err := getReputationScorer(params)
+	if err != nil {
+		return 0, err
+	}
+
+	reputation, err := getReputation(ctx, publicKeyHash, params, timestamp)
+	if err != nil {
+		return 0, err
+	}
+
+	previousScore := reputation.TrustScore
+	booked, decay := stepReputation(reputation, scorer, params, event, timestamp)
+
+	if err := putReputation(ctx, reputation); err != nil {
+		return 0, err
+	}
+
+	change := &ReputationChange{
+		EvidenceID: event.EvidenceID,
+		Cause:      event.Cause,
+		Detail:     event.Detail,
+		Weight:     booked,
+		Decay:      decay,
+		Delta:      reputation.TrustScore - previousScore,
+		TrustScore: reputation.TrustScore,
+		Model:      params.ReputationModel,
+	}
+	if err := recordReputationChange(ctx, publicKeyHash, change, timestamp); err != nil {
+		return 0, err
+	}
+	return booked, nil
+}
+
+// stepReputation applies one event to a reputation in memory and returns the weight booked
+// and the score drift since the previous event
+func stepReputation(
+	reputation *Reputation,
+	scorer reputationScorer,
+	params *ChainProofParams,
+	event reputationEvent,
+	timestamp int64,
+) (int, int) {
+	previousScore := reputation.TrustScore
+	reputation.TrustScore = scorer.current(reputation, params, timestamp)
+	decay := reputation.TrustScore - previousScore
+
+	countReputationEvent(reputation, event.Cause, timestamp)
+	booked := scorer.apply(reputation, event, params)
+	reputation.ScoringModel = params.ReputationModel
+	reputation.LastUpdatedAt = timestamp
+	return booked, decay
+}
+
+// currentTrustScore returns a reputation's score as of now under the configured model (read-only)
+func currentTrustScore(reputation *Reputation, params *ChainProofParams, now int64) (int, error) {
+	scorer, err := getReputationScorer(params)
+	if err != nil {
+		return 0, err
+	}
+	return scorer.current(reputation, params, now), nil
+}
+
+// countReputationEvent updates the submission counters for an event
+func countReputationEvent(reputation *Reputation, cause string, timestamp int64) {
+	switch cause {
+	case ReputationCauseSubmitted:
+		reputation.TotalSubmissions++
+		reputation.LastSubmissionAt = timestamp
+	case ReputationCauseVerified:
+		reputation.VerifiedSubmissions++
+	case ReputationCauseVerificationReversed:
+		reputation.VerifiedSubmissions = max(0, reputation.VerifiedSubmissions-1)
+	case ReputationCauseRejected:
+		reputation.RejectedSubmissions++
+	case ReputationCauseRejectionReversed:
+		reputation.RejectedSubmissions = max(0, reputation.RejectedSubmissions-1)
+	case ReputationCauseWithdrawn:
+		reputation.WithdrawnSubmissions++
+	case ReputationCauseExported:
+		reputation.ExportedSubmissions++
+	}
+}
+
+// bookReputationWeight adds weight to the positive or negative total
+// A reversal removes weight from the total the original event was booked on.
+func bookReputationWeight(reputation *Reputation, weight int, reversal bool) {
+	switch {
+	case reversal && weight < 0:
+		reputation.PositiveWeight = max(0, reputation.PositiveWeight+weight)
+	case reversal && weight > 0:
+		reputation.NegativeWeight = max(0, reputation.NegativeWeight-weight)
+	case weight > 0:
+		reputation.PositiveWeight += weight
+	case weight < 0:
+		reputation.NegativeWeight -= weight
+	}
+}
+
+// betaMeanScore returns 100 * (a0 + positive) / (a0 + b0 + positive + negative), rounded,
+// where the prior a0 + b0 = ReputationPriorStrength is split according to InitialTrustScore
+func betaMeanScore(reputation *Reputation, params *ChainProofParams) int {
+	priorPositive := int64(params.ReputationPriorStrength * params.InitialTrustScore)
+	priorNegative := int64(params.ReputationPriorStrength * (maxTrustScore - params.InitialTrustScore))
+	positive := priorPositive + int64(reputation.PositiveWeight)*maxTrustScore
+	total := priorPositive + priorNegative + int64(reputation.PositiveWeight+reputation.NegativeWeight)*maxTrustScore
+	return clampTrustScore(int((positive*maxTrustScore + total/2) / total))
+}
+
+// halfLifeFactor returns 2^(-elapsed/halfLife) in parts per million
+// Whole half-lives halve the factor; the remainder interpolates between eighths.
+func halfLifeFactor(elapsed int64, halfLife int64) int64 {
+	if elapsed <= 0 {
+		return ppm
+	}
+	halvings := elapsed / halfLife
+	if halvings >= 20 {
+		return 0
+	}
+
+	eighths := (elapsed % halfLife) * 8 * ppm / halfLife
+	step, remainder := eighths/ppm, eighths%ppm
+	factor := halfLifeSteps[step] - (halfLifeSteps[step]-halfLifeSteps[step+1])*remainder/ppm
+	return factor >> uint(halvings)
+}
+
+// clampTrustScore bounds a score to 0-100
+func clampTrustScore(score int) int {
+	return max(0, min(maxTrustScore, score))
+}
+
+// newReputation returns the record of a key seen for the first time
+func newReputation(publicKeyHash string, params *ChainProofParams, timestamp int64) *Reputation {
+	return &Reputation{
+		DocType:           "reputation",
+		PublicKeyHash:     publicKeyHash,
+		TrustScore:        params.InitialTrustScore,
+		ScoringModel:      params.ReputationModel,
+		FirstSubmissionAt: timestamp,
+		LastUpdatedAt:     timestamp,
+	}
+}
+
+// getReputation reads a key's reputation, starting a new record when none exists
+func getReputation(
+	ctx contractapi.TransactionContextInterface,
+	publicKeyHash string,
+	params *ChainProofParams,
+	timestamp int64,
+) (*Reputation, error) {
+	reputation, err := findReputation(ctx, publicKeyHash)
+	if err != nil {
+		return nil, err
+	}
+	if reputation == nil {
+		return newReputation(publicKeyHash, params, timestamp), nil
+	}
+	return reputation, nil
+}
+
+// findReputation reads a key's stored reputation, or nil when none exists
+func findReputation(ctx contractapi.TransactionContextInterface, publicKeyHash string) (*Reputation, error) {
+	collection, err := orgCollection(ctx, OrgRoleSubmitter)
+	if err != nil {
+		return nil, err
+	}
+	reputationJSON, err := readPrivateData(ctx, collection, "reputation_"+publicKeyHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reputation from PDC: %v", err)
+	}
+	if reputationJSON == nil {
+		return nil, nil
+	}
+
+	var reputation Reputation
+	if err := json.Unmarshal(reputationJSON, &reputation); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal reputation: %v", err)
+	}
+	return &reputation, nil
+}
+
+// putReputation stores a key's reputation
+func putReputation(ctx contractapi.TransactionContextInterface, reputation *Reputation) error {
+	collection, err := orgCollection(ctx, OrgRoleSubmitter)
+	if err != nil {
+		return err
+	}
+	reputationJSON, err := json.Marshal(reputation)
+	if err != nil {
+		return fmt.Errorf("failed to marshal reputation: %v", err)
+	}
+	return writePrivateData(ctx, collection, "reputation_"+reputation.PublicKeyHash, reputationJSON)
+}
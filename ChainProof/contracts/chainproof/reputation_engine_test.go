@@ -0,0 +1,142 @@
+package main
+
+import "testing"
+
+func TestReputationScorerApply(t *testing.T) {
+	tests := []struct {
+		name         string
+		model        string
+		start        Reputation
+		event        reputationEvent
+		wantScore    int
+		wantBooked   int
+		wantPositive int
+		wantNegative int
+	}{
+		{"additive reward", ReputationModelAdditive,
+			Reputation{TrustScore: 50}, reputationEvent{Weight: 10}, 60, 10, 10, 0},
+		{"additive reward clamped at 100", ReputationModelAdditive,
+			Reputation{TrustScore: 95}, reputationEvent{Weight: 10}, 100, 5, 5, 0},
+		{"additive penalty clamped at 0", ReputationModelAdditive,
+			Reputation{TrustScore: 5}, reputationEvent{Weight: -15}, 0, -5, 0, 5},
+		{"additive reversal of a reward", ReputationModelAdditive,
+			Reputation{TrustScore: 60, PositiveWeight: 10}, reputationEvent{Weight: -10, Reversal: true}, 50, -10, 0, 0},
+		{"additive reversal of a penalty", ReputationModelAdditive,
+			Reputation{TrustScore: 35, NegativeWeight: 15}, reputationEvent{Weight: 15, Reversal: true}, 50, 15, 0, 0},
+		{"additive zero weight", ReputationModelAdditive,
+			Reputation{TrustScore: 42}, reputationEvent{Weight: 0}, 42, 0, 0, 0},
+
+		{"bayesian reward", ReputationModelBayesian,
+			Reputation{TrustScore: 50}, reputationEvent{Weight: 10}, 67, 10, 10, 0},
+		{"bayesian penalty", ReputationModelBayesian,
+			Reputation{TrustScore: 50}, reputationEvent{Weight: -15}, 29, -15, 0, 15},
+		{"bayesian mixed history", ReputationModelBayesian,
+			Reputation{TrustScore: 67, PositiveWeight: 10}, reputationEvent{Weight: -15}, 44, -15, 10, 15},
+		{"bayesian reversal of a penalty", ReputationModelBayesian,
+			Reputation{TrustScore: 29, NegativeWeight: 15}, reputationEvent{Weight: 15, Reversal: true}, 50, 15, 0, 0},
+
+		{"decay books like additive", ReputationModelDecay,
+			Reputation{TrustScore: 50}, reputationEvent{Weight: -15}, 35, -15, 0, 15},
+		{"decay clamps like additive", ReputationModelDecay,
+			Reputation{TrustScore: 98}, reputationEvent{Weight: 3}, 100, 2, 2, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := defaultParams()
+			params.ReputationModel = tt.model
+			scorer, err := getReputationScorer(params)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			reputation := tt.start
+			booked := scorer.apply(&reputation, tt.event, params)
+			if reputation.TrustScore != tt.wantScore || booked != tt.wantBooked {
+				t.Fatalf("score %d booked %d, want score %d booked %d", reputation.TrustScore, booked, tt.wantScore, tt.wantBooked)
+			}
+			if reputation.PositiveWeight != tt.wantPositive || reputation.NegativeWeight != tt.wantNegative {
+				t.Fatalf("weights +%d -%d, want +%d -%d", reputation.PositiveWeight, reputation.NegativeWeight, tt.wantPositive, tt.wantNegative)
+			}
+		})
+	}
+}
+
+func TestReputationScorerCurrent(t *testing.T) {
+	const now = 1700000000
+	halfLife := int64(DefaultReputationHalfLifeSeconds)
+
+	tests := []struct {
+		name  string
+		model string
+		start Reputation
+		want  int
+	}{
+		{"additive ignores elapsed time", ReputationModelAdditive,
+			Reputation{TrustScore: 70, LastUpdatedAt: now - 10*halfLife}, 70},
+		{"bayesian recomputes from weights", ReputationModelBayesian,
+			Reputation{TrustScore: 99, PositiveWeight: 10, NegativeWeight: 15, LastUpdatedAt: now - halfLife}, 44},
+		{"decay without elapsed time", ReputationModelDecay,
+			Reputation{TrustScore: 70, LastUpdatedAt: now}, 70},
+		{"decay after half a half-life", ReputationModelDecay,
+			Reputation{TrustScore: 70, LastUpdatedAt: now - halfLife/2}, 64},
+		{"decay after one half-life", ReputationModelDecay,
+			Reputation{TrustScore: 70, LastUpdatedAt: now - halfLife}, 60},
+		{"decay after two half-lives", ReputationModelDecay,
+			Reputation{TrustScore: 70, LastUpdatedAt: now - 2*halfLife}, 55},
+		{"decay recovers towards the initial score", ReputationModelDecay,
+			Reputation{TrustScore: 30, LastUpdatedAt: now - halfLife}, 40},
+		{"decay fully relaxed", ReputationModelDecay,
+			Reputation{TrustScore: 90, LastUpdatedAt: now - 25*halfLife}, 50},
+		{"decay of a record never updated", ReputationModelDecay,
+			Reputation{TrustScore: 80}, 80},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := defaultParams()
+			params.ReputationModel = tt.model
+			scorer, err := getReputationScorer(params)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			reputation := tt.start
+			if got := scorer.current(&reputation, params, now); got != tt.want {
+				t.Fatalf("current = %d, want %d", got, tt.want)
+			}
+			if reputation.TrustScore != tt.start.TrustScore {
+				t.Fatalf("current changed the stored score to %d", reputation.TrustScore)
+			}
+		})
+	}
+}
+
+func TestGetReputationScorer(t *testing.T) {
+	tests := []struct {
+		model   string
+		want    reputationScorer
+		wantErr bool
+	}{
+		{ReputationModelAdditive, additiveScorer{}, false},
+		{ReputationModelBayesian, bayesianScorer{}, false},
+		{ReputationModelDecay, decayScorer{}, false},
+		{"LINEAR", nil, true},
+		{"", nil, true},
+	}
+
+	for _, tt := range tests {
+		params := defaultParams()
+		params.ReputationModel = tt.model
+		scorer, err := getReputationScorer(params)
+		if tt.wantErr {
+			if err == nil {
+				t.Fatalf("model %q: expected an error", tt.model)
+			}
+			continue
+		}
+		if err != nil || scorer != tt.want {
+			t.Fatalf("model %q: got %T (%v), want %T", tt.model, scorer, err, tt.want)
+		}
+	}
+}
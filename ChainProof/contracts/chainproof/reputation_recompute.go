@@ -0,0 +1,371 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// =============================================================================
+// ChainProof - Reputation Recomputation
+// =============================================================================
+// Reputation lives only in WhistleblowerPrivateCollection. A failed update now
+// fails its transaction, but records written while updates were best-effort
+// can have drifted from what actually happened to the key's evidence. Every outcome that moves reputation is also visible
+// in the public Evidence record's history (verification, appeal, hash
+// mismatch, withdrawal, verdict, export), so the record can be rebuilt by
+// replaying those versions through the reputation engine under the active
+// parameters. Differences caused by parameter changes since the events show
+// up like any other drift. A correction writes the rebuilt record and a
+// RECOMPUTED entry in the key's reputation history.
+//
+// Fabric re-validates neither rich query results nor GetHistoryForKey at
+// commit, so a correction re-reads every evidence key it replayed with
+// GetState. An evidence update committed in between then invalidates the
+// correction instead of letting it overwrite newer reputation with a stale
+// replay. New evidence for the key moves the stored reputation, which the
+// correction also read. The batch variant uses a paginated query, after
+// which Fabric forbids writes, so it only reports.
+// =============================================================================
+
+// timedReputationEvent is a reputation event derived from an evidence version
+type timedReputationEvent struct {
+	event     reputationEvent
+	timestamp int64
+}
+
+// RecomputeReputation rebuilds one key's reputation from its public evidence and
+// reports how it differs from the stored record; with correct=true the stored record is replaced
+func (c *WhistleblowerContract) RecomputeReputation(
+	ctx contractapi.TransactionContextInterface,
+	publicKeyHash string,
+	correct bool,
+) (*ReputationRecomputation, error) {
+	if err := RequireWhistleblowerOrg(ctx, RoleSupervisor); err != nil {
+		return nil, err
+	}
+	if publicKeyHash == "" {
+		return nil, fmt.Errorf("publicKeyHash is required")
+	}
+
+	return recomputeReputation(ctx, publicKeyHash, correct)
+}
+
+// RecomputeReputations reports drift for the keys that submitted one page of public evidence
+// It never writes (Fabric rejects writes after a paginated query); correct each
+// differing key with RecomputeReputation. A key whose evidence spans several
+// pages is reported on each of them.
+func (c *WhistleblowerContract) RecomputeReputations(
+	ctx contractapi.TransactionContextInterface,
+	pageSize int32,
+	bookmark string,
+) (*ReputationRecomputationPage, error) {
+	if err := RequireWhistleblowerOrg(ctx, RoleSupervisor); err != nil {
+		return nil, err
+	}
+
+	queryString, err := NewSelector("evidence").Build()
+	if err != nil {
+		return nil, err
+	}
+	page, err := getQueryResultWithPagination(ctx, queryString, pageSize, bookmark)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []*ReputationRecomputation{}
+	seen := map[string]bool{}
+	for _, evidence := range page.Records {
+		if evidence.PublicKeyHash == "" || seen[evidence.PublicKeyHash] {
+			continue // Legacy evidence (including unsigned bulk items) carries no key
+		}
+		seen[evidence.PublicKeyHash] = true
+
+		result, err := recomputeReputation(ctx, evidence.PublicKeyHash, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to recompute reputation for %s: %v", evidence.PublicKeyHash, err)
+		}
+		results = append(results, result)
+	}
+
+	return &ReputationRecomputationPage{
+		Results:             results,
+		FetchedRecordsCount: page.FetchedRecordsCount,
+		Bookmark:            page.Bookmark,
+	}, nil
+}
+
+// recomputeReputation replays a key's evidence history, compares and optionally corrects
+func recomputeReputation(
+	ctx contractapi.TransactionContextInterface,
+	publicKeyHash string,
+	correct bool,
+) (*ReputationRecomputation, error) {
+	params, err := getParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	scorer, err := getReputationScorer(params)
+	if err != nil {
+		return nil, err
+	}
+	timestamp, err := getTxTimestamp(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	evidenceIds, err := getEvidenceIDsByKey(ctx, publicKeyHash)
+	if err != nil {
+		return nil, err
+	}
+	events := []*timedReputationEvent{}
+	for _, evidenceId := range evidenceIds {
+		versions, err := getEvidenceVersions(ctx, evidenceId)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, reputationEventsFromVersions(versions, params)...)
+	}
+	// Stable, so each evidence record's own events keep their order within a second
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].timestamp < events[j].timestamp
+	})
+
+	recomputed := replayReputation(publicKeyHash, events, scorer, params)
+	recomputed.TrustScore = scorer.current(recomputed, params, timestamp)
+	recomputed.LastUpdatedAt = timestamp
+
+	stored, err := findReputation(ctx, publicKeyHash)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReputationRecomputation{
+		PublicKeyHash: publicKeyHash,
+		EvidenceCount: len(evidenceIds),
+		EventCount:    len(events),
+		Stored:        stored,
+		Recomputed:    recomputed,
+		Differences:   diffReputation(stored, recomputed, scorer, params, timestamp),
+		CheckedAt:     timestamp,
+	}
+	if !correct || len(result.Differences) == 0 {
+		return result, nil
+	}
+
+	// Put every replayed evidence key in the read set (see file header)
+	for _, evidenceId := range evidenceIds {
+		if _, err := ctx.GetStub().GetState(evidenceId); err != nil {
+			return nil, fmt.Errorf("failed to read evidence %s: %v", evidenceId, err)
+		}
+	}
+
+	if err := putReputation(ctx, recomputed); err != nil {
+		return nil, err
+	}
+	previousScore := params.InitialTrustScore
+	if stored != nil {
+		previousScore = scorer.current(stored, params, timestamp)
+	}
+	change := &ReputationChange{
+		Cause:      ReputationCauseRecomputed,
+		Detail:     fmt.Sprintf("Rebuilt from %d evidence records (%d differences)", len(evidenceIds), len(result.Differences)),
+		Delta:      recomputed.TrustScore - previousScore,
+		TrustScore: recomputed.TrustScore,
+		Model:      params.ReputationModel,
+	}
+	if err := recordReputationChange(ctx, publicKeyHash, change, timestamp); err != nil {
+		return nil, err
+	}
+	result.Corrected = true
+	return result, nil
+}
+
+// replayReputation applies events to a fresh reputation record
+func replayReputation(
+	publicKeyHash string,
+	events []*timedReputationEvent,
+	scorer reputationScorer,
+	params *ChainProofParams,
+) *Reputation {
+	reputation := newReputation(publicKeyHash, params, 0)
+	if len(events) > 0 {
+		reputation.FirstSubmissionAt = events[0].timestamp
+		reputation.LastUpdatedAt = events[0].timestamp
+	}
+
+	// Reversals undo the weight booked for that evidence's last verdict or rejection
+	verdictWeights := map[string]int{}
+	rejectionWeights := map[string]int{}
+	for _, timed := range events {
+		event := timed.event
+		switch event.Cause {
+		case ReputationCauseVerdictReversed:
+			event.Weight = -verdictWeights[event.EvidenceID]
+		case ReputationCauseRejectionReversed:
+			event.Weight = -rejectionWeights[event.EvidenceID]
+		}
+		booked, _ := stepReputation(reputation, scorer, params, event, timed.timestamp)
+		switch event.Cause {
+		case ReputationCauseLegalVerdict:
+			verdictWeights[event.EvidenceID] = booked
+		case ReputationCauseVerdictReversed:
+			verdictWeights[event.EvidenceID] = 0
+		case ReputationCauseRejected:
+			rejectionWeights[event.EvidenceID] = booked
+		case ReputationCauseRejectionReversed:
+			rejectionWeights[event.EvidenceID] = 0
+		}
+	}
+	return reputation
+}
+
+// reputationEventsFromVersions derives the reputation events behind each change between
+// consecutive versions of an evidence record (newest first), mirroring the live update rules
+func reputationEventsFromVersions(versions []*HistoryEntry, params *ChainProofParams) []*timedReputationEvent {
+	events := []*timedReputationEvent{}
+	var previous *Evidence
+	for i := len(versions) - 1; i >= 0; i-- {
+		version := versions[i]
+		current := version.Value
+		if version.IsDelete || current == nil {
+			continue
+		}
+		add := func(cause string, detail string, weight int, reversal bool) {
+			events = append(events, &timedReputationEvent{
+				event: reputationEvent{
+					EvidenceID: current.EvidenceID,
+					Cause:      cause,
+					Detail:     detail,
+					Weight:     weight,
+					Reversal:   reversal,
+				},
+				timestamp: version.Timestamp,
+			})
+		}
+
+		if previous == nil {
+			add(ReputationCauseSubmitted, "Evidence submitted", 0, false)
+			previous = current
+			continue
+		}
+
+		if current.IntegrityStatus == IntegrityVerified && previous.IntegrityStatus != IntegrityVerified {
+			switch {
+			case previous.ReverifyStatus == ReverifyPending:
+				// Hash re-confirmed after a legal-stage mismatch; already credited
+			case previous.AppealStatus == AppealPending:
+				add(ReputationCauseRejectionReversed, "Appeal upheld", 0, true)
+				add(ReputationCauseVerified, "Verification passed", params.ReputationVerifyReward, false)
+			default:
+				add(ReputationCauseVerified, "Verification passed", params.ReputationVerifyReward, false)
+			}
+		}
+
+		if current.IntegrityStatus == IntegrityFailed && previous.IntegrityStatus != IntegrityFailed {
+			switch {
+			case previous.AppealStatus == AppealPending:
+				// Appeal denied; the original penalty stands
+			case previous.ReverifyStatus == ReverifyPending:
+				add(ReputationCauseVerificationReversed, "Legal-stage hash mismatch confirmed", -params.ReputationVerifyReward, true)
+				add(ReputationCauseRejected, "Verification failed", -params.ReputationRejectPenalty, false)
+			default:
+				add(ReputationCauseRejected, "Verification failed", -params.ReputationRejectPenalty, false)
+			}
+		}
+
+		if current.ReviewedAt != previous.ReviewedAt {
+			if previous.Verdict != "" {
+				add(ReputationCauseVerdictReversed, fmt.Sprintf("Legal verdict %s reversed", previous.Verdict), 0, true)
+			}
+			if current.Verdict != "" {
+				add(ReputationCauseLegalVerdict, fmt.Sprintf("Legal verdict %s", current.Verdict),
+					verdictReputationEffect(params, current.Verdict), false)
+			}
+		}
+
+		if current.Status == StatusWithdrawn && previous.Status != StatusWithdrawn {
+			add(ReputationCauseWithdrawn, "Evidence withdrawn", 0, false)
+		}
+		if current.Status == StatusExported && previous.Status != StatusExported {
+			add(ReputationCauseExported, "Evidence exported for court", 0, false)
+		}
+
+		previous = current
+	}
+	return events
+}
+
+// diffReputation lists the fields where the stored record disagrees with the recomputed one
+// Trust scores are compared as of now, so DECAY drift since the last event is not a difference.
+func diffReputation(
+	stored *Reputation,
+	recomputed *Reputation,
+	scorer reputationScorer,
+	params *ChainProofParams,
+	now int64,
+) []*ReputationDifference {
+	if stored == nil {
+		stored = &Reputation{TrustScore: params.InitialTrustScore}
+	}
+	storedScore := scorer.current(stored, params, now)
+
+	fields := []struct {
+		name       string
+		stored     int64
+		recomputed int64
+	}{
+		{"totalSubmissions", int64(stored.TotalSubmissions), int64(recomputed.TotalSubmissions)},
+		{"verifiedSubmissions", int64(stored.VerifiedSubmissions), int64(recomputed.VerifiedSubmissions)},
+		{"rejectedSubmissions", int64(stored.RejectedSubmissions), int64(recomputed.RejectedSubmissions)},
+		{"withdrawnSubmissions", int64(stored.WithdrawnSubmissions), int64(recomputed.WithdrawnSubmissions)},
+		{"exportedSubmissions", int64(stored.ExportedSubmissions), int64(recomputed.ExportedSubmissions)},
+		{"trustScore", int64(storedScore), int64(recomputed.TrustScore)},
+		{"positiveWeight", int64(stored.PositiveWeight), int64(recomputed.PositiveWeight)},
+		{"negativeWeight", int64(stored.NegativeWeight), int64(recomputed.NegativeWeight)},
+		{"firstSubmissionAt", stored.FirstSubmissionAt, recomputed.FirstSubmissionAt},
+		{"lastSubmissionAt", stored.LastSubmissionAt, recomputed.LastSubmissionAt},
+	}
+
+	differences := []*ReputationDifference{}
+	for _, field := range fields {
+		if field.stored != field.recomputed {
+			differences = append(differences, &ReputationDifference{
+				Field:      field.name,
+				Stored:     field.stored,
+				Recomputed: field.recomputed,
+			})
+		}
+	}
+	return differences
+}
+
+// getEvidenceIDsByKey returns the IDs of every evidence record submitted with a key
+func getEvidenceIDsByKey(ctx contractapi.TransactionContextInterface, publicKeyHash string) ([]string, error) {
+	queryString, err := NewSelector("evidence").Equals("publicKeyHash", publicKeyHash).Build()
+	if err != nil {
+		return nil, err
+	}
+	resultsIterator, err := ctx.GetStub().GetQueryResult(queryString)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query evidence for key: %v", err)
+	}
+	defer resultsIterator.Close()
+
+	evidenceIds := []string{}
+	for resultsIterator.HasNext() {
+		queryResult, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		var evidence Evidence
+		if err := json.Unmarshal(queryResult.Value, &evidence); err != nil {
+			return nil, err
+		}
+		evidenceIds = append(evidenceIds, evidence.EvidenceID)
+	}
+	return evidenceIds, nil
+}
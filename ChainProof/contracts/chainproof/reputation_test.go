@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestReputationHistoryBulkSubmission(t *testing.T) {
+	tests := []struct {
+		name  string
+		items int
+	}{
+		{"single item", 1},
+		{"two items", 2},
+		{"eleven items", 11},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := newLedgerStub()
+			relay := &testIdentity{mspID: WhistleblowersOrgMSP, id: "relay"}
+			key := newECDSATestKey(t, false)
+			contract := new(WhistleblowerContract)
+
+			items := []BulkEvidenceItem{}
+			for i := 0; i < tt.items; i++ {
+				items = append(items, BulkEvidenceItem{
+					EvidenceID: fmt.Sprintf("EVD%d", tt.items-i), // Reverse order, so key order alone would misorder them
+					IPFSCID:    fmt.Sprintf("Qm%d", i),
+					FileHash:   fmt.Sprintf("%064x", i),
+					FileType:   "image",
+					FileSize:   1,
+					Category:   "corruption",
+				})
+			}
+			itemsJSON, err := json.Marshal(items)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			stub.begin("txBulk")
+			if _, err := contract.SubmitBulkEvidence(newTestContext(stub, relay), "BULK1", string(itemsJSON),
+				key.hash, key.pemKey, key.sign(BulkSubmissionSignedMessage("BULK1", items))); err != nil {
+				t.Fatal(err)
+			}
+
+			stub.begin("txHistory")
+			history, err := contract.GetReputationHistory(newTestContext(stub, relay), key.pemKey, stub.now,
+				key.sign(BuildKeyChallenge(ChallengeGetReputationHistory, key.hash, "", stub.now)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(history) != tt.items {
+				t.Fatalf("%d history records, want one per item (%d)", len(history), tt.items)
+			}
+			for i, change := range history {
+				if change.Cause != ReputationCauseSubmitted || change.EvidenceID != items[i].EvidenceID || change.TxID != "txBulk" {
+					t.Fatalf("record %d is %s for %s in %s, want %s for %s in txBulk",
+						i, change.Cause, change.EvidenceID, change.TxID, ReputationCauseSubmitted, items[i].EvidenceID)
+				}
+			}
+		})
+	}
+}
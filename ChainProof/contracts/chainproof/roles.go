@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// =============================================================================
+// ChainProof - Attribute-Based Roles
+// =============================================================================
+// Inside VerifierOrg and LegalOrg, and for WhistleblowersOrg administration,
+// what an identity may do is set by the chainproof.role certificate attribute
+// issued by the org's CA, e.g.
+//   fabric-ca-client register --id.attrs 'chainproof.role=reviewer:ecert'
+// Several roles can be granted as a comma-separated list. Each contract method
+// declares the roles it accepts in its Require*Org call; the caller needs any
+// one of them. Org administrators (certificate OU "admin") hold every role.
+// =============================================================================
+
+// RoleAttribute is the certificate attribute carrying an identity's roles
+const RoleAttribute = "chainproof.role"
+
+// Roles within an organization
+const (
+	RoleReviewer   = "reviewer"   // Day-to-day verification and legal review work, comments and notes
+	RoleSupervisor = "supervisor" // Assignments, reopening reviews and governance votes
+	RoleExporter   = "exporter"   // Requesting and approving court exports
+	RoleAuditor    = "auditor"    // Read-only access to private notes, comments and justifications
+)
+
+// adminOU is the Fabric NodeOU identifying organization administrators
+const adminOU = "admin"
+
+// RequireRole ensures the caller holds at least one of the given roles
+// With no roles given any identity of the calling org is accepted.
+func RequireRole(ctx contractapi.TransactionContextInterface, roles ...string) error {
+	if len(roles) == 0 || isOrgAdmin(ctx) {
+		return nil
+	}
+
+	for _, role := range roles {
+		if HasRole(ctx, role) {
+			return nil
+		}
+	}
+
+	granted, _ := GetClientRoles(ctx)
+	return fmt.Errorf("access denied: caller roles %v do not include any of %v (attribute %s)", granted, roles, RoleAttribute)
+}
+
+// HasRole checks if the caller's chainproof.role attribute grants a role (non-throwing)
+func HasRole(ctx contractapi.TransactionContextInterface, role string) bool {
+	if ctx.GetClientIdentity().AssertAttributeValue(RoleAttribute, role) == nil {
+		return true
+	}
+
+	granted, _ := GetClientRoles(ctx)
+	for _, g := range granted {
+		if g == role {
+			return true
+		}
+	}
+	return false
+}
+
+// GetClientRoles returns the roles listed in the caller's chainproof.role attribute
+func GetClientRoles(ctx contractapi.TransactionContextInterface) ([]string, error) {
+	value, found, err := ctx.GetClientIdentity().GetAttributeValue(RoleAttribute)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s attribute: %v", RoleAttribute, err)
+	}
+	if !found {
+		return []string{}, nil
+	}
+
+	roles := []string{}
+	for _, role := range strings.Split(value, ",") {
+		if role = strings.ToLower(strings.TrimSpace(role)); role != "" {
+			roles = append(roles, role)
+		}
+	}
+	return roles, nil
+}
+
+// isOrgAdmin checks if the caller's certificate carries the admin NodeOU
+func isOrgAdmin(ctx contractapi.TransactionContextInterface) bool {
+	cert, err := ctx.GetClientIdentity().GetX509Certificate()
+	if err != nil || cert == nil {
+		return false
+	}
+	for _, ou := range cert.Subject.OrganizationalUnit {
+		if strings.EqualFold(ou, adminOU) {
+			return true
+		}
+	}
+	return false
+}
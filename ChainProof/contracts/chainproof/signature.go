@@ -0,0 +1,264 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// =============================================================================
+// ChainProof - Pseudonymous Key Verification
+// =============================================================================
+// Whistleblowers are identified only by the SHA256 hash of their public key.
+// These helpers bind a submitted public key to its hash and verify signatures
+// made with the matching private key (ECDSA P-256 or Ed25519).
+// =============================================================================
+
+// Supported key algorithms
+const (
+	KeyAlgorithmECDSAP256 = "ECDSA_P256"
+	KeyAlgorithmEd25519   = "ED25519"
+)
+
+// KeyChallengeWindowSeconds bounds how far a signed challenge timestamp may be from the tx timestamp
+const KeyChallengeWindowSeconds = 300
+
+// Key ownership challenge actions (bound into the signed message so a proof cannot be reused elsewhere)
+const (
+	ChallengeGetNotifications     = "GET_NOTIFICATIONS"
+	ChallengeMarkNotificationRead = "MARK_NOTIFICATION_READ"
+	ChallengeGetReputation        = "GET_REPUTATION"
+	ChallengeGetReputationHistory = "GET_REPUTATION_HISTORY"
+	ChallengeAppealRejection      = "APPEAL_REJECTION"
+	ChallengeWithdrawEvidence     = "WITHDRAW_EVIDENCE"
+	ChallengeSubmitEvidence       = "SUBMIT"
+	ChallengeSupersedeEvidence    = "SUPERSEDE_EVIDENCE"
+	ChallengeBulkSubmit           = "BULK_SUBMIT"
+)
+
+// Distinct errors so clients can tell forgery apart from malformed input
+var (
+	ErrPublicKeyMismatch = errors.New("public key does not match publicKeyHash")
+	ErrInvalidSignature  = errors.New("signature verification failed")
+	ErrChallengeExpired  = errors.New("key ownership challenge expired")
+)
+
+// PseudonymousKey is a parsed whistleblower public key
+type PseudonymousKey struct {
+	Algorithm string
+	Hash      string // hex SHA256 of the DER-encoded SubjectPublicKeyInfo
+	key       interface{}
+}
+
+// ParsePseudonymousKey parses a PEM or base64 DER SubjectPublicKeyInfo.
+// Only ECDSA P-256 and Ed25519 keys are accepted.
+func ParsePseudonymousKey(publicKey string) (*PseudonymousKey, error) {
+	der, err := decodePublicKeyDER(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %v", err)
+	}
+
+	hash := sha256.Sum256(der)
+	key := &PseudonymousKey{Hash: hex.EncodeToString(hash[:]), key: parsed}
+
+	switch pub := parsed.(type) {
+	case *ecdsa.PublicKey:
+		if pub.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("unsupported ECDSA curve %s, only P-256 is accepted", pub.Curve.Params().Name)
+		}
+		key.Algorithm = KeyAlgorithmECDSAP256
+	case ed25519.PublicKey:
+		key.Algorithm = KeyAlgorithmEd25519
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", parsed)
+	}
+
+	return key, nil
+}
+
+// MatchesHash checks the key against a claimed publicKeyHash
+func (k *PseudonymousKey) MatchesHash(publicKeyHash string) error {
+	if !strings.EqualFold(k.Hash, publicKeyHash) {
+		return fmt.Errorf("%w: computed %s, claimed %s", ErrPublicKeyMismatch, k.Hash, publicKeyHash)
+	}
+	return nil
+}
+
+// Verify checks a base64 signature over message.
+// ECDSA signatures may be ASN.1 DER or raw r||s (as produced by WebCrypto) over SHA256(message).
+func (k *PseudonymousKey) Verify(message []byte, signature string) error {
+	sig, err := decodeBase64(signature)
+	if err != nil {
+		return fmt.Errorf("%w: signature is not valid base64", ErrInvalidSignature)
+	}
+
+	var valid bool
+	switch pub := k.key.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(message)
+		if len(sig) == 64 {
+			r := new(big.Int).SetBytes(sig[:32])
+			s := new(big.Int).SetBytes(sig[32:])
+			valid = ecdsa.Verify(pub, digest[:], r, s)
+		} else {
+			valid = ecdsa.VerifyASN1(pub, digest[:], sig)
+		}
+	case ed25519.PublicKey:
+		valid = len(sig) == ed25519.SignatureSize && ed25519.Verify(pub, message, sig)
+	}
+
+	if !valid {
+		return fmt.Errorf("%w: signature does not verify with key %s", ErrInvalidSignature, k.Hash)
+	}
+	return nil
+}
+
+// verifyPseudonymousSignature parses publicKey, binds it to publicKeyHash and verifies signature over message
+func verifyPseudonymousSignature(publicKey string, publicKeyHash string, message []byte, signature string) (*PseudonymousKey, error) {
+	key, err := ParsePseudonymousKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := key.MatchesHash(publicKeyHash); err != nil {
+		return nil, err
+	}
+	if err := key.Verify(message, signature); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// BuildKeyChallenge returns the message a key holder signs to prove ownership of publicKeyHash.
+// Format: chainproof:<action>:<publicKeyHash>:<subject>:<challengeTimestamp>
+// subject scopes the proof to one record (e.g. a notification ID) and is empty for key-wide reads.
+func BuildKeyChallenge(action string, publicKeyHash string, subject string, challengeTimestamp int64) []byte {
+	return []byte(fmt.Sprintf("chainproof:%s:%s:%s:%d", action, strings.ToLower(publicKeyHash), subject, challengeTimestamp))
+}
+
+// SubmissionSignedMessage is the message a submitter signs for SubmitEvidence.
+// Format: chainproof:SUBMIT:<evidenceId>:<ipfsCid>:<fileHash>. A new version signs
+// chainproof:SUPERSEDE_EVIDENCE:<supersedesEvidenceId>:<evidenceId>:<ipfsCid>:<fileHash>.
+// The signature is stored publicly, so it binds the record it creates and cannot be
+// replayed under another evidence ID or CID.
+func SubmissionSignedMessage(evidenceId string, ipfsCid string, fileHash string, supersedesEvidenceId string) []byte {
+	if supersedesEvidenceId == "" {
+		return []byte(fmt.Sprintf("chainproof:%s:%s:%s:%s", ChallengeSubmitEvidence, evidenceId, ipfsCid, fileHash))
+	}
+	return []byte(fmt.Sprintf("chainproof:%s:%s:%s:%s:%s", ChallengeSupersedeEvidence, supersedesEvidenceId, evidenceId, ipfsCid, fileHash))
+}
+
+// BulkSubmissionSignedMessage is the message a submitter signs for SubmitBulkEvidence.
+// Format: chainproof:BULK_SUBMIT:<bulkSubmissionId>:<evidenceId>:<ipfsCid>:<fileHash>,... with
+// one evidenceId:ipfsCid:fileHash triple per item, in order.
+func BulkSubmissionSignedMessage(bulkSubmissionId string, items []BulkEvidenceItem) []byte {
+	triples := make([]string, len(items))
+	for i, item := range items {
+		triples[i] = fmt.Sprintf("%s:%s:%s", item.EvidenceID, item.IPFSCID, item.FileHash)
+	}
+	return []byte(fmt.Sprintf("chainproof:%s:%s:%s", ChallengeBulkSubmit, bulkSubmissionId, strings.Join(triples, ",")))
+}
+
+// validateSignedField rejects empty values and the separators of signed submission messages,
+// so one signed message cannot be split into a different set of fields
+func validateSignedField(name string, value string) error {
+	if value == "" {
+		return fmt.Errorf("%s is required", name)
+	}
+	if strings.ContainsAny(value, ":,") {
+		return fmt.Errorf("%s must not contain ':' or ','", name)
+	}
+	return nil
+}
+
+// ReasonChallengeSubject binds a signature to the evidence and the exact reason text
+func ReasonChallengeSubject(evidenceId string, reason string) string {
+	reasonHash := sha256.Sum256([]byte(reason))
+	return evidenceId + ":" + hex.EncodeToString(reasonHash[:])
+}
+
+// verifyKeyOwnership checks a signed, fresh challenge and returns the caller's publicKeyHash.
+// The Node backend relays every whistleblower through one org identity, so this signature
+// (not MSP membership) is what authorizes access to a pseudonym's notifications and reputation.
+func verifyKeyOwnership(
+	ctx contractapi.TransactionContextInterface,
+	publicKey string,
+	action string,
+	subject string,
+	challengeTimestamp int64,
+	signature string,
+) (string, error) {
+	key, err := ParsePseudonymousKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+
+	timestamp, err := getTxTimestamp(ctx)
+	if err != nil {
+		return "", err
+	}
+	drift := timestamp - challengeTimestamp
+	if drift < 0 {
+		drift = -drift
+	}
+	if drift > KeyChallengeWindowSeconds {
+		return "", fmt.Errorf("%w: challenge timestamp %d is more than %ds from transaction time %d",
+			ErrChallengeExpired, challengeTimestamp, KeyChallengeWindowSeconds, timestamp)
+	}
+
+	if err := key.Verify(BuildKeyChallenge(action, key.Hash, subject, challengeTimestamp), signature); err != nil {
+		return "", err
+	}
+
+	return key.Hash, nil
+}
+
+// decodePublicKeyDER accepts a PEM block or bare base64 DER
+func decodePublicKeyDER(publicKey string) ([]byte, error) {
+	publicKey = strings.TrimSpace(publicKey)
+	if publicKey == "" {
+		return nil, fmt.Errorf("publicKey is required")
+	}
+
+	if strings.HasPrefix(publicKey, "-----BEGIN") {
+		block, _ := pem.Decode([]byte(publicKey))
+		if block == nil || block.Type != "PUBLIC KEY" {
+			return nil, fmt.Errorf("publicKey PEM must contain a PUBLIC KEY block")
+		}
+		return block.Bytes, nil
+	}
+
+	der, err := decodeBase64(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("publicKey must be PEM or base64 DER: %v", err)
+	}
+	return der, nil
+}
+
+// decodeBase64 accepts standard or URL-safe base64, padded or not
+func decodeBase64(value string) ([]byte, error) {
+	value = strings.TrimSpace(value)
+	for _, enc := range []*base64.Encoding{
+		base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding,
+	} {
+		if decoded, err := enc.DecodeString(value); err == nil {
+			return decoded, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid base64")
+}
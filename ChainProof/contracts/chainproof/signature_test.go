@@ -0,0 +1,269 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// testKey is a whistleblower key pair in the encodings clients submit
+type testKey struct {
+	pemKey string // PEM SubjectPublicKeyInfo
+	derKey string // base64 DER SubjectPublicKeyInfo
+	hash   string // hex SHA256 of the DER
+	sign   func(message []byte) string
+}
+
+// newTestKey wraps a public key and its signer
+func newTestKey(t *testing.T, public interface{}, sign func(message []byte) []byte) *testKey {
+	der, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := sha256.Sum256(der)
+	return &testKey{
+		pemKey: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+		derKey: base64.StdEncoding.EncodeToString(der),
+		hash:   hex.EncodeToString(hash[:]),
+		sign: func(message []byte) string {
+			return base64.StdEncoding.EncodeToString(sign(message))
+		},
+	}
+}
+
+// newECDSATestKey returns a P-256 key; raw selects WebCrypto r||s signatures instead of ASN.1
+func newECDSATestKey(t *testing.T, raw bool) *testKey {
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newTestKey(t, &private.PublicKey, func(message []byte) []byte {
+		digest := sha256.Sum256(message)
+		if !raw {
+			sig, err := ecdsa.SignASN1(rand.Reader, private, digest[:])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return sig
+		}
+		r, s, err := ecdsa.Sign(rand.Reader, private, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig := make([]byte, 64)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:])
+		return sig
+	})
+}
+
+// newEd25519TestKey returns an Ed25519 key
+func newEd25519TestKey(t *testing.T) *testKey {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newTestKey(t, public, func(message []byte) []byte {
+		return ed25519.Sign(private, message)
+	})
+}
+
+func TestParsePseudonymousKey(t *testing.T) {
+	ecKey := newECDSATestKey(t, false)
+	edKey := newEd25519TestKey(t)
+
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p384DER, _ := x509.MarshalPKIXPublicKey(&p384.PublicKey)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaDER, _ := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+
+	tests := []struct {
+		name      string
+		publicKey string
+		wantAlg   string
+		wantHash  string
+		wantErr   string
+	}{
+		{"ECDSA PEM", ecKey.pemKey, KeyAlgorithmECDSAP256, ecKey.hash, ""},
+		{"ECDSA base64 DER", ecKey.derKey, KeyAlgorithmECDSAP256, ecKey.hash, ""},
+		{"ECDSA URL-safe unpadded DER", strings.TrimRight(strings.NewReplacer("+", "-", "/", "_").Replace(ecKey.derKey), "="), KeyAlgorithmECDSAP256, ecKey.hash, ""},
+		{"Ed25519 PEM", edKey.pemKey, KeyAlgorithmEd25519, edKey.hash, ""},
+		{"Ed25519 base64 DER", edKey.derKey, KeyAlgorithmEd25519, edKey.hash, ""},
+		{"empty", "  ", "", "", "publicKey is required"},
+		{"not base64", "not a key!", "", "", "must be PEM or base64 DER"},
+		{"wrong PEM block", "-----BEGIN CERTIFICATE-----\nAAAA\n-----END CERTIFICATE-----\n", "", "", "PUBLIC KEY block"},
+		{"garbage DER", base64.StdEncoding.EncodeToString([]byte("garbage")), "", "", "failed to parse public key"},
+		{"P-384 curve", base64.StdEncoding.EncodeToString(p384DER), "", "", "unsupported ECDSA curve P-384"},
+		{"RSA key", base64.StdEncoding.EncodeToString(rsaDER), "", "", "unsupported public key type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := ParsePseudonymousKey(tt.publicKey)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if key.Algorithm != tt.wantAlg || key.Hash != tt.wantHash {
+				t.Fatalf("parsed %s %s, want %s %s", key.Algorithm, key.Hash, tt.wantAlg, tt.wantHash)
+			}
+		})
+	}
+}
+
+func TestVerifyPseudonymousSignature(t *testing.T) {
+	ecASN1 := newECDSATestKey(t, false)
+	ecRaw := newECDSATestKey(t, true)
+	edKey := newEd25519TestKey(t)
+	message := []byte("9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08")
+	other := []byte("chainproof:GET_REPUTATION:abc::1700000000")
+
+	tests := []struct {
+		name      string
+		publicKey string
+		keyHash   string
+		message   []byte
+		signature string
+		wantErr   error
+	}{
+		{"ECDSA ASN.1 signature", ecASN1.pemKey, ecASN1.hash, message, ecASN1.sign(message), nil},
+		{"ECDSA raw r||s signature", ecRaw.derKey, ecRaw.hash, message, ecRaw.sign(message), nil},
+		{"Ed25519 signature", edKey.pemKey, edKey.hash, message, edKey.sign(message), nil},
+		{"hash compared case-insensitively", ecASN1.pemKey, strings.ToUpper(ecASN1.hash), message, ecASN1.sign(message), nil},
+		{"key does not match hash", ecASN1.pemKey, edKey.hash, message, ecASN1.sign(message), ErrPublicKeyMismatch},
+		{"signature over another message", ecRaw.pemKey, ecRaw.hash, message, ecRaw.sign(other), ErrInvalidSignature},
+		{"signature by another key", edKey.pemKey, edKey.hash, message, ecASN1.sign(message), ErrInvalidSignature},
+		{"signature not base64", ecASN1.pemKey, ecASN1.hash, message, "%%%", ErrInvalidSignature},
+		{"truncated Ed25519 signature", edKey.pemKey, edKey.hash, message, edKey.sign(message)[:40], ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := verifyPseudonymousSignature(tt.publicKey, tt.keyHash, tt.message, tt.signature)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.EqualFold(key.Hash, tt.keyHash) {
+				t.Fatalf("verified key %s, want %s", key.Hash, tt.keyHash)
+			}
+		})
+	}
+}
+
+func TestSubmissionSignatureBinding(t *testing.T) {
+	key := newECDSATestKey(t, false)
+	signed := key.sign(SubmissionSignedMessage("EVD1", "QmGood", "abc", ""))
+
+	tests := []struct {
+		name       string
+		evidenceId string
+		ipfsCid    string
+		fileHash   string
+		signature  string
+		wantErr    string
+	}{
+		{"signed record", "EVD1", "QmGood", "abc", signed, ""},
+		{"replayed under another evidence ID", "EVD2", "QmGood", "abc", signed, "signature verification failed"},
+		{"replayed with another CID", "EVD1", "QmBogus", "abc", signed, "signature verification failed"},
+		{"bare fileHash signature", "EVD1", "QmGood", "abc", key.sign([]byte("abc")), "signature verification failed"},
+		{"supersede signature on a plain submission", "EVD1", "QmGood", "abc",
+			key.sign(SubmissionSignedMessage("EVD1", "QmGood", "abc", "EVD0")), "signature verification failed"},
+		{"separator in evidence ID", "EVD1:QmGood", "abc", "abc", signed, "evidenceId must not contain"},
+		{"missing CID", "EVD1", "", "abc", signed, "ipfsCid is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := newLedgerStub()
+			stub.begin("tx1")
+			ctx := newTestContext(stub, &testIdentity{mspID: WhistleblowersOrgMSP, id: "relay"})
+
+			err := new(WhistleblowerContract).SubmitEvidence(ctx, tt.evidenceId, tt.ipfsCid, tt.fileHash,
+				"document", 10, "corruption", "d", key.hash, key.pemKey, tt.signature, "")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBulkSubmissionSignatureBinding(t *testing.T) {
+	key := newECDSATestKey(t, false)
+	items := []BulkEvidenceItem{
+		{EvidenceID: "EVD1", IPFSCID: "QmOne", FileHash: "aaa", FileType: "image", FileSize: 1, Category: "corruption"},
+		{EvidenceID: "EVD2", IPFSCID: "QmTwo", FileHash: "bbb", FileType: "image", FileSize: 1, Category: "corruption"},
+	}
+	signed := key.sign(BulkSubmissionSignedMessage("BULK1", items))
+
+	swapped := []BulkEvidenceItem{items[0], items[1]}
+	swapped[1].IPFSCID = "QmBogus"
+	renamed := []BulkEvidenceItem{items[0], items[1]}
+	renamed[0].EvidenceID = "EVD9"
+
+	tests := []struct {
+		name    string
+		items   []BulkEvidenceItem
+		wantErr string
+	}{
+		{"signed batch", items, ""},
+		{"item CID replaced", swapped, "signature verification failed"},
+		{"item evidence ID replaced", renamed, "signature verification failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := newLedgerStub()
+			stub.begin("tx1")
+			ctx := newTestContext(stub, &testIdentity{mspID: WhistleblowersOrgMSP, id: "relay"})
+			itemsJSON, err := json.Marshal(tt.items)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = new(WhistleblowerContract).SubmitBulkEvidence(ctx, "BULK1", string(itemsJSON), key.hash, key.pemKey, signed)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// =============================================================================
+// ChainProof - Assignment and SLA Tracking
+// =============================================================================
+// VerifierOrg and LegalOrg assign a responsible identity (certificate ID hash)
+// and a due date per stage. Stage SLAs come from the ledger parameters and are
+// measured from SubmittedAt (verification), MismatchReportedAt (re-verification
+// after a legal-stage hash mismatch) and VerifiedAt (legal review). An assigned
+// due date replaces the SLA deadline, so it can extend it as well as shorten it.
+// =============================================================================
+
+// slaStart names the evidence field a stage's SLA is measured from for some of its statuses
+type slaStart struct {
+	statuses []string
+	field    string // Evidence JSON field the SLA is measured from
+}
+
+// slaStage describes which statuses belong to a stage and where its deadlines are kept
+type slaStage struct {
+	starts   []slaStart
+	dueField string // Evidence JSON field holding the assigned due date
+}
+
+// slaStages maps each stage to its statuses and timestamps
+var slaStages = map[string]slaStage{
+	SLAStageVerification: {
+		starts: []slaStart{
+			{statuses: []string{StatusSubmitted, StatusDisputed, StatusAppealed}, field: "submittedAt"},
+			{statuses: []string{StatusReverify}, field: "mismatchReportedAt"},
+		},
+		dueField: "verificationDueAt",
+	},
+	SLAStageReview: {
+		starts: []slaStart{
+			{statuses: []string{StatusVerified, StatusUnderReview}, field: "verifiedAt"},
+		},
+		dueField: "reviewDueAt",
+	},
+}
+
+// statuses returns every status that belongs to the stage
+func (s slaStage) statuses() []string {
+	statuses := []string{}
+	for _, start := range s.starts {
+		statuses = append(statuses, start.statuses...)
+	}
+	return statuses
+}
+
+// AssignVerifier records the verifier identity responsible for an evidence item
+// dueAt is a Unix timestamp; 0 uses SubmittedAt (MismatchReportedAt for re-verification) + the verification SLA.
+func (c *VerifierContract) AssignVerifier(
+	ctx contractapi.TransactionContextInterface,
+	evidenceId string,
+	assigneeFingerprint string,
+	dueAt int64,
+) error {
+	if err := RequireVerifierOrg(ctx, RoleSupervisor); err != nil {
+		return err
+	}
+
+	return assignEvidence(ctx, evidenceId, SLAStageVerification, assigneeFingerprint, dueAt)
+}
+
+// AssignReviewer records the legal reviewer identity responsible for an evidence item
+// dueAt is a Unix timestamp; 0 uses VerifiedAt + the review SLA.
+func (c *LegalContract) AssignReviewer(
+	ctx contractapi.TransactionContextInterface,
+	evidenceId string,
+	assigneeFingerprint string,
+	dueAt int64,
+) error {
+	if err := RequireLegalOrg(ctx, RoleSupervisor); err != nil {
+		return err
+	}
+
+	return assignEvidence(ctx, evidenceId, SLAStageReview, assigneeFingerprint, dueAt)
+}
+
+// QueryOverdueEvidence lists evidence past its SLA or assigned due date for a stage
+// (VERIFICATION or REVIEW), with pagination
+func (c *QueryContract) QueryOverdueEvidence(
+	ctx contractapi.TransactionContextInterface,
+	stage string,
+	pageSize int32,
+	bookmark string,
+) (*OverdueEvidenceQueryResult, error) {
+	if err := RequireAnyOrg(ctx); err != nil {
+		return nil, err
+	}
+
+	stageDef, ok := slaStages[stage]
+	if !ok {
+		return nil, fmt.Errorf("unknown SLA stage %s: expected %s or %s", stage, SLAStageVerification, SLAStageReview)
+	}
+
+	params, err := getParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	timestamp, err := getTxTimestamp(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sla := stageSLA(params, stage)
+	cutoff := max64(1, timestamp-sla)
+
+	// Overdue = an assigned due date has passed, or with no due date assigned,
+	// the stage started more than one SLA ago
+	overdue := []*Selector{
+		NewCondition().In("status", stageDef.statuses()).Between(stageDef.dueField, 1, timestamp),
+	}
+	for _, start := range stageDef.starts {
+		overdue = append(overdue,
+			NewCondition().In("status", start.statuses).Between(stageDef.dueField, 0, 0).Between(start.field, 1, cutoff),
+			NewCondition().In("status", start.statuses).Absent(stageDef.dueField).Between(start.field, 1, cutoff),
+		)
+	}
+	queryString, err := NewSelector("evidence").AnyOf(overdue...).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := getQueryResultWithPagination(ctx, queryString, pageSize, bookmark)
+	if err != nil {
+		return nil, err
+	}
+
+	records := []*OverdueEvidence{}
+	for _, evidence := range page.Records {
+		records = append(records, describeOverdue(evidence, stage, sla, timestamp))
+	}
+
+	return &OverdueEvidenceQueryResult{
+		Stage:               stage,
+		CheckedAt:           timestamp,
+		Records:             records,
+		FetchedRecordsCount: len(records),
+		Bookmark:            page.Bookmark,
+	}, nil
+}
+
+// assignEvidence records the assignee and due date for a stage
+func assignEvidence(
+	ctx contractapi.TransactionContextInterface,
+	evidenceId string,
+	stage string,
+	assigneeFingerprint string,
+	dueAt int64,
+) error {
+	assignee, err := normalizeFingerprint(assigneeFingerprint)
+	if err != nil {
+		return err
+	}
+
+	evidence, err := getEvidence(ctx, evidenceId)
+	if err != nil {
+		return err
+	}
+
+	action := TransitionAssignVerifier
+	if stage == SLAStageReview {
+		action = TransitionAssignReviewer
+	}
+	if _, err := applyTransition(ctx, evidence, action); err != nil {
+		return err
+	}
+
+	timestamp, err := getTxTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+	params, err := getParams(ctx)
+	if err != nil {
+		return err
+	}
+
+	if dueAt == 0 {
+		dueAt = stageStartedAt(evidence, stage) + stageSLA(params, stage)
+	} else if dueAt <= timestamp {
+		return fmt.Errorf("due date %d must be in the future (now %d)", dueAt, timestamp)
+	}
+
+	if stage == SLAStageReview {
+		evidence.ReviewAssignee = assignee
+		evidence.ReviewAssignedAt = timestamp
+		evidence.ReviewDueAt = dueAt
+	} else {
+		evidence.VerificationAssignee = assignee
+		evidence.VerificationAssignedAt = timestamp
+		evidence.VerificationDueAt = dueAt
+	}
+
+	if err := putEvidence(ctx, evidence); err != nil {
+		return err
+	}
+
+	return appendCustodyLog(ctx, evidenceId, ActionAssign,
+		fmt.Sprintf("%s assigned to %s, due %d", stage, assignee, dueAt), timestamp)
+}
+
+// describeOverdue summarizes why an evidence item is overdue
+func describeOverdue(evidence *Evidence, stage string, sla int64, now int64) *OverdueEvidence {
+	startedAt := stageStartedAt(evidence, stage)
+	overdue := &OverdueEvidence{
+		EvidenceID:     evidence.EvidenceID,
+		Status:         evidence.Status,
+		Stage:          stage,
+		StageStartedAt: startedAt,
+		SLADueAt:       startedAt + sla,
+	}
+	if stage == SLAStageReview {
+		overdue.Assignee = evidence.ReviewAssignee
+		overdue.AssignedDueAt = evidence.ReviewDueAt
+	} else {
+		overdue.Assignee = evidence.VerificationAssignee
+		overdue.AssignedDueAt = evidence.VerificationDueAt
+	}
+
+	deadline := overdue.SLADueAt
+	if overdue.AssignedDueAt > 0 {
+		deadline = overdue.AssignedDueAt
+	}
+	overdue.OverdueSeconds = now - deadline
+	return overdue
+}
+
+// stageStartedAt returns the timestamp a stage's SLA is measured from
+func stageStartedAt(evidence *Evidence, stage string) int64 {
+	if stage == SLAStageReview {
+		return evidence.VerifiedAt
+	}
+	if evidence.Status == StatusReverify {
+		return evidence.MismatchReportedAt
+	}
+	return evidence.SubmittedAt
+}
+
+// stageSLA returns the configured SLA for a stage in seconds
+func stageSLA(params *ChainProofParams, stage string) int64 {
+	if stage == SLAStageReview {
+		return params.ReviewSLASeconds
+	}
+	return params.VerificationSLASeconds
+}
+
+// max64 returns the larger of two int64 values
+func max64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
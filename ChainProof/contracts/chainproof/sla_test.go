@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestQueryOverdueEvidence(t *testing.T) {
+	const day = 24 * 3600
+	stub := newLedgerStub()
+	stub.begin("txSeed")
+	now := stub.now + 1 // Transaction time of the query below
+
+	// Documents are stored as-is; legacy ones predate the due date fields
+	documents := []struct {
+		evidence Evidence
+		legacy   bool
+	}{
+		{Evidence{EvidenceID: "SUB-LATE", Status: StatusSubmitted, SubmittedAt: now - 4*day}, false},
+		{Evidence{EvidenceID: "SUB-FRESH", Status: StatusSubmitted, SubmittedAt: now - day}, false},
+		{Evidence{EvidenceID: "SUB-EXTENDED", Status: StatusSubmitted, SubmittedAt: now - 4*day, VerificationDueAt: now + day}, false},
+		{Evidence{EvidenceID: "SUB-SHORTENED", Status: StatusSubmitted, SubmittedAt: now - day, VerificationDueAt: now - 3600}, false},
+		{Evidence{EvidenceID: "SUB-LEGACY", Status: StatusSubmitted, SubmittedAt: now - 4*day}, true},
+		{Evidence{EvidenceID: "APPEAL-LATE", Status: StatusAppealed, SubmittedAt: now - 5*day}, false},
+		{Evidence{EvidenceID: "REVERIFY-FRESH", Status: StatusReverify, SubmittedAt: now - 30*day, VerifiedAt: now - 20*day, MismatchReportedAt: now - day}, false},
+		{Evidence{EvidenceID: "REVERIFY-LATE", Status: StatusReverify, SubmittedAt: now - 30*day, VerifiedAt: now - 20*day, MismatchReportedAt: now - 4*day}, false},
+		{Evidence{EvidenceID: "REV-LATE", Status: StatusUnderReview, SubmittedAt: now - 30*day, VerifiedAt: now - 15*day}, false},
+		{Evidence{EvidenceID: "REV-EXTENDED", Status: StatusVerified, SubmittedAt: now - 30*day, VerifiedAt: now - 15*day, ReviewDueAt: now + day}, false},
+		{Evidence{EvidenceID: "REV-FRESH", Status: StatusVerified, SubmittedAt: now - 30*day, VerifiedAt: now - day}, false},
+		{Evidence{EvidenceID: "DONE", Status: StatusExported, SubmittedAt: now - 30*day, VerifiedAt: now - 20*day}, false},
+	}
+
+	for _, document := range documents {
+		document.evidence.DocType = "evidence"
+		documentJSON, err := json.Marshal(document.evidence)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if document.legacy {
+			var fields map[string]interface{}
+			if err := json.Unmarshal(documentJSON, &fields); err != nil {
+				t.Fatal(err)
+			}
+			delete(fields, "verificationDueAt")
+			delete(fields, "reviewDueAt")
+			if documentJSON, err = json.Marshal(fields); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := stub.PutState(document.evidence.EvidenceID, documentJSON); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		stage   string
+		overdue map[string]int64 // Evidence ID -> expected OverdueSeconds
+	}{
+		{SLAStageVerification, map[string]int64{
+			"SUB-LATE":      day,
+			"SUB-SHORTENED": 3600,
+			"SUB-LEGACY":    day,
+			"APPEAL-LATE":   2 * day,
+			"REVERIFY-LATE": day,
+		}},
+		{SLAStageReview, map[string]int64{
+			"REV-LATE": day,
+		}},
+	}
+
+	stub.begin("txQuery")
+	for _, tt := range tests {
+		t.Run(tt.stage, func(t *testing.T) {
+			ctx := newTestContext(stub, &testIdentity{mspID: VerifierOrgMSP, id: "supervisor"})
+			result, err := new(QueryContract).QueryOverdueEvidence(ctx, tt.stage, 50, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			found := map[string]int64{}
+			for _, record := range result.Records {
+				found[record.EvidenceID] = record.OverdueSeconds
+			}
+			for id, want := range tt.overdue {
+				got, ok := found[id]
+				if !ok {
+					t.Errorf("%s is not reported overdue", id)
+				} else if got != want {
+					t.Errorf("%s overdue by %d seconds, want %d", id, got, want)
+				}
+			}
+			for id := range found {
+				if _, ok := tt.overdue[id]; !ok {
+					t.Errorf("%s is reported overdue", id)
+				}
+			}
+		})
+	}
+}
+
+func TestAssignVerifierDefaultsReverifyDueDate(t *testing.T) {
+	stub := newLedgerStub()
+	stub.begin("txSeed")
+	reportedAt := stub.now - 3600
+	evidenceJSON, err := json.Marshal(Evidence{
+		DocType:            "evidence",
+		EvidenceID:         "EVD1",
+		Status:             StatusReverify,
+		SubmittedAt:        stub.now - 30*24*3600,
+		MismatchReportedAt: reportedAt,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stub.PutState("EVD1", evidenceJSON); err != nil {
+		t.Fatal(err)
+	}
+
+	stub.begin("txAssign")
+	supervisor := &testIdentity{mspID: VerifierOrgMSP, id: "supervisor", attrs: map[string]string{RoleAttribute: RoleSupervisor}}
+	if err := new(VerifierContract).AssignVerifier(newTestContext(stub, supervisor), "EVD1", strings.Repeat("ab", 32), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	evidence, err := getEvidence(newTestContext(stub, supervisor), "EVD1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := reportedAt + DefaultVerificationSLASeconds; evidence.VerificationDueAt != want {
+		t.Fatalf("due at %d, want the mismatch report plus the SLA (%d)", evidence.VerificationDueAt, want)
+	}
+}
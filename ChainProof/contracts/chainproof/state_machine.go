@@ -0,0 +1,261 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/chainproof/chaincode/events"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// =============================================================================
+// ChainProof - Evidence Lifecycle State Machine
+// =============================================================================
+// Every status change goes through one declarative transition table.
+// Mutating methods look up (current status, action) and the organization roles
+// the registry gives the caller's MSP instead of hand-coding status checks.
+// =============================================================================
+
+// Lifecycle Action Constants (transition table keys)
+const (
+	TransitionAttest         = "ATTEST"
+	TransitionVerifyPass     = "VERIFY_PASS"
+	TransitionVerifyFail     = "VERIFY_FAIL"
+	TransitionVerifyDispute  = "VERIFY_DISPUTE"
+	TransitionAppeal         = "APPEAL"
+	TransitionWithdraw       = "WITHDRAW"
+	TransitionSupersede      = "SUPERSEDE"
+	TransitionAssignVerifier = "ASSIGN_VERIFIER"
+	TransitionAssignReviewer = "ASSIGN_REVIEWER"
+	TransitionStartReview    = "START_REVIEW"
+	TransitionCompleteReview = "COMPLETE_REVIEW"
+	TransitionReopenReview   = "REOPEN_REVIEW"
+	TransitionHashMismatch   = "REPORT_HASH_MISMATCH"
+	TransitionRequestExport  = "REQUEST_EXPORT"
+	TransitionExport         = "EXPORT"
+)
+
+// ErrCodeIllegalTransition prefixes every TransitionError message
+const ErrCodeIllegalTransition = "ILLEGAL_TRANSITION"
+
+// Transition is one allowed edge of the evidence lifecycle
+type Transition struct {
+	From        string `json:"from"`        // Current evidence status
+	Action      string `json:"action"`      // Lifecycle action
+	AllowedRole string `json:"allowedRole"` // Organization role (OrgRole*) allowed to take the action
+	To          string `json:"to"`          // Resulting evidence status
+}
+
+// evidenceTransitions is the complete evidence lifecycle
+var evidenceTransitions = []Transition{
+	// Integrity verification: attestations collect until the quorum decides the outcome
+	{From: StatusSubmitted, Action: TransitionAttest, AllowedRole: OrgRoleVerifier, To: StatusSubmitted},
+	{From: StatusSubmitted, Action: TransitionVerifyPass, AllowedRole: OrgRoleVerifier, To: StatusVerified},
+	{From: StatusSubmitted, Action: TransitionVerifyFail, AllowedRole: OrgRoleVerifier, To: StatusRejected},
+	{From: StatusSubmitted, Action: TransitionVerifyDispute, AllowedRole: OrgRoleVerifier, To: StatusDisputed},
+	{From: StatusDisputed, Action: TransitionAttest, AllowedRole: OrgRoleVerifier, To: StatusDisputed},
+	{From: StatusDisputed, Action: TransitionVerifyPass, AllowedRole: OrgRoleVerifier, To: StatusVerified},
+	{From: StatusDisputed, Action: TransitionVerifyFail, AllowedRole: OrgRoleVerifier, To: StatusRejected},
+	{From: StatusDisputed, Action: TransitionVerifyDispute, AllowedRole: OrgRoleVerifier, To: StatusDisputed},
+
+	// Appeal of a rejection: re-verified by verifiers who did not take part in the rejection
+	{From: StatusRejected, Action: TransitionAppeal, AllowedRole: OrgRoleSubmitter, To: StatusAppealed},
+	{From: StatusAppealed, Action: TransitionAttest, AllowedRole: OrgRoleVerifier, To: StatusAppealed},
+	{From: StatusAppealed, Action: TransitionVerifyPass, AllowedRole: OrgRoleVerifier, To: StatusVerified},
+	{From: StatusAppealed, Action: TransitionVerifyFail, AllowedRole: OrgRoleVerifier, To: StatusRejected},
+	{From: StatusAppealed, Action: TransitionVerifyDispute, AllowedRole: OrgRoleVerifier, To: StatusDisputed},
+
+	// Legal review
+	{From: StatusVerified, Action: TransitionStartReview, AllowedRole: OrgRoleLegal, To: StatusUnderReview},
+	{From: StatusUnderReview, Action: TransitionStartReview, AllowedRole: OrgRoleLegal, To: StatusUnderReview},
+	{From: StatusVerified, Action: TransitionCompleteReview, AllowedRole: OrgRoleLegal, To: StatusReviewed},
+	{From: StatusUnderReview, Action: TransitionCompleteReview, AllowedRole: OrgRoleLegal, To: StatusReviewed},
+	{From: StatusReviewed, Action: TransitionReopenReview, AllowedRole: OrgRoleLegal, To: StatusUnderReview},
+
+	// Hash mismatch found by the legal team: export is blocked until VerifierOrg re-verifies
+	{From: StatusVerified, Action: TransitionHashMismatch, AllowedRole: OrgRoleLegal, To: StatusReverify},
+	{From: StatusUnderReview, Action: TransitionHashMismatch, AllowedRole: OrgRoleLegal, To: StatusReverify},
+	{From: StatusReviewed, Action: TransitionHashMismatch, AllowedRole: OrgRoleLegal, To: StatusReverify},
+	{From: StatusExported, Action: TransitionHashMismatch, AllowedRole: OrgRoleLegal, To: StatusReverify},
+	{From: StatusReverify, Action: TransitionAttest, AllowedRole: OrgRoleVerifier, To: StatusReverify},
+	{From: StatusReverify, Action: TransitionVerifyPass, AllowedRole: OrgRoleVerifier, To: StatusVerified},
+	{From: StatusReverify, Action: TransitionVerifyFail, AllowedRole: OrgRoleVerifier, To: StatusRejected},
+	{From: StatusReverify, Action: TransitionVerifyDispute, AllowedRole: OrgRoleVerifier, To: StatusDisputed},
+
+	// Court export (re-export allowed), requested by one LegalOrg identity and approved by another
+	{From: StatusReviewed, Action: TransitionRequestExport, AllowedRole: OrgRoleLegal, To: StatusReviewed},
+	{From: StatusExported, Action: TransitionRequestExport, AllowedRole: OrgRoleLegal, To: StatusExported},
+	{From: StatusReviewed, Action: TransitionExport, AllowedRole: OrgRoleLegal, To: StatusExported},
+	{From: StatusExported, Action: TransitionExport, AllowedRole: OrgRoleLegal, To: StatusExported},
+
+	// Withdrawal by the submitter, allowed from any status before export
+	{From: StatusSubmitted, Action: TransitionWithdraw, AllowedRole: OrgRoleSubmitter, To: StatusWithdrawn},
+	{From: StatusDisputed, Action: TransitionWithdraw, AllowedRole: OrgRoleSubmitter, To: StatusWithdrawn},
+	{From: StatusVerified, Action: TransitionWithdraw, AllowedRole: OrgRoleSubmitter, To: StatusWithdrawn},
+	{From: StatusRejected, Action: TransitionWithdraw, AllowedRole: OrgRoleSubmitter, To: StatusWithdrawn},
+	{From: StatusAppealed, Action: TransitionWithdraw, AllowedRole: OrgRoleSubmitter, To: StatusWithdrawn},
+	{From: StatusUnderReview, Action: TransitionWithdraw, AllowedRole: OrgRoleSubmitter, To: StatusWithdrawn},
+	{From: StatusReviewed, Action: TransitionWithdraw, AllowedRole: OrgRoleSubmitter, To: StatusWithdrawn},
+	{From: StatusReverify, Action: TransitionWithdraw, AllowedRole: OrgRoleSubmitter, To: StatusWithdrawn},
+
+	// Assignment of a responsible identity (status unchanged)
+	{From: StatusSubmitted, Action: TransitionAssignVerifier, AllowedRole: OrgRoleVerifier, To: StatusSubmitted},
+	{From: StatusDisputed, Action: TransitionAssignVerifier, AllowedRole: OrgRoleVerifier, To: StatusDisputed},
+	{From: StatusAppealed, Action: TransitionAssignVerifier, AllowedRole: OrgRoleVerifier, To: StatusAppealed},
+	{From: StatusReverify, Action: TransitionAssignVerifier, AllowedRole: OrgRoleVerifier, To: StatusReverify},
+	{From: StatusVerified, Action: TransitionAssignReviewer, AllowedRole: OrgRoleLegal, To: StatusVerified},
+	{From: StatusUnderReview, Action: TransitionAssignReviewer, AllowedRole: OrgRoleLegal, To: StatusUnderReview},
+
+	// Supersession by a newer version from the same key, allowed before legal review starts
+	{From: StatusSubmitted, Action: TransitionSupersede, AllowedRole: OrgRoleSubmitter, To: StatusSuperseded},
+	{From: StatusDisputed, Action: TransitionSupersede, AllowedRole: OrgRoleSubmitter, To: StatusSuperseded},
+	{From: StatusVerified, Action: TransitionSupersede, AllowedRole: OrgRoleSubmitter, To: StatusSuperseded},
+	{From: StatusRejected, Action: TransitionSupersede, AllowedRole: OrgRoleSubmitter, To: StatusSuperseded},
+	{From: StatusAppealed, Action: TransitionSupersede, AllowedRole: OrgRoleSubmitter, To: StatusSuperseded},
+	{From: StatusReverify, Action: TransitionSupersede, AllowedRole: OrgRoleSubmitter, To: StatusSuperseded},
+}
+
+// closedStatuses end the workflow: no transitions leave them and no side records may be added
+var closedStatuses = map[string]bool{
+	StatusWithdrawn:  true,
+	StatusSuperseded: true,
+}
+
+// requireOpenEvidence refuses workflow activity (notes, comments, anchors) on closed evidence
+func requireOpenEvidence(evidence *Evidence) error {
+	if closedStatuses[evidence.Status] {
+		return fmt.Errorf("evidence %s is %s; no further workflow actions are allowed", evidence.EvidenceID, evidence.Status)
+	}
+	return nil
+}
+
+// TransitionError is returned for any action the table does not allow
+type TransitionError struct {
+	EvidenceID     string   `json:"evidenceId"`
+	Status         string   `json:"status"`
+	Action         string   `json:"action"`
+	CallerMSP      string   `json:"callerMsp"`
+	Reason         string   `json:"reason"`
+	AllowedActions []string `json:"allowedActions"` // Actions the caller may take from this status
+}
+
+// Error renders as "ILLEGAL_TRANSITION: {json}" so clients can parse the details
+func (e *TransitionError) Error() string {
+	details, _ := json.Marshal(e)
+	return fmt.Sprintf("%s: %s", ErrCodeIllegalTransition, details)
+}
+
+// AllowedTransitionsResult lists the actions the calling org can take next
+type AllowedTransitionsResult struct {
+	EvidenceID  string       `json:"evidenceId"`
+	Status      string       `json:"status"`
+	CallerMSP   string       `json:"callerMsp"`
+	Transitions []Transition `json:"transitions"`
+}
+
+// findTransitions returns the table entries leaving status, optionally filtered by action and organization roles
+// A nil roles slice matches every role.
+func findTransitions(status string, action string, roles []string) []Transition {
+	matches := []Transition{}
+	for _, t := range evidenceTransitions {
+		if t.From != status {
+			continue
+		}
+		if action != "" && t.Action != action {
+			continue
+		}
+		if roles != nil && !containsString(roles, t.AllowedRole) {
+			continue
+		}
+		matches = append(matches, t)
+	}
+	return matches
+}
+
+// checkTransition validates action against the table for the caller without changing evidence
+func checkTransition(
+	ctx contractapi.TransactionContextInterface,
+	evidence *Evidence,
+	action string,
+) (*Transition, error) {
+	callerMSP, callerRoles, err := getCallerOrgRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	transitionErr := func(reason string) error {
+		allowed := []string{}
+		for _, t := range findTransitions(evidence.Status, "", callerRoles) {
+			allowed = append(allowed, t.Action)
+		}
+		return &TransitionError{
+			EvidenceID:     evidence.EvidenceID,
+			Status:         evidence.Status,
+			Action:         action,
+			CallerMSP:      callerMSP,
+			Reason:         reason,
+			AllowedActions: allowed,
+		}
+	}
+
+	candidates := findTransitions(evidence.Status, action, nil)
+	if len(candidates) == 0 {
+		return nil, transitionErr(fmt.Sprintf("action %s is not allowed from status %s", action, evidence.Status))
+	}
+
+	for _, t := range candidates {
+		if containsString(callerRoles, t.AllowedRole) {
+			transition := t
+			return &transition, nil
+		}
+	}
+	return nil, transitionErr(fmt.Sprintf("organization %s may not take action %s", callerMSP, action))
+}
+
+// applyTransition validates action and moves evidence to the resulting status
+func applyTransition(
+	ctx contractapi.TransactionContextInterface,
+	evidence *Evidence,
+	action string,
+) (*Transition, error) {
+	transition, err := checkTransition(ctx, evidence, action)
+	if err != nil {
+		return nil, err
+	}
+	evidence.Status = transition.To
+
+	if eventType, ok := transitionEvents[action]; ok {
+		if err := emitEvent(ctx, &events.Event{
+			Type:       eventType,
+			EvidenceID: evidence.EvidenceID,
+			OldStatus:  transition.From,
+			NewStatus:  transition.To,
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return transition, nil
+}
+
+// getCallerOrgRoles returns the caller's MSP and the organization roles the registry gives it
+func getCallerOrgRoles(ctx contractapi.TransactionContextInterface) (string, []string, error) {
+	callerMSP, err := GetClientOrgID(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	registry, err := getOrgRegistry(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	return callerMSP, registry.rolesOf(callerMSP), nil
+}
+
+// containsString checks if values holds value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
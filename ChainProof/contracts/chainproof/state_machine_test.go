@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckTransition(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  string
+		action  string
+		mspID   string
+		wantTo  string
+		wantErr string // Substring of the TransitionError reason; empty when allowed
+	}{
+		{"verifier passes submitted", StatusSubmitted, TransitionVerifyPass, VerifierOrgMSP, StatusVerified, ""},
+		{"verifier fails submitted", StatusSubmitted, TransitionVerifyFail, VerifierOrgMSP, StatusRejected, ""},
+		{"attestation keeps status", StatusDisputed, TransitionAttest, VerifierOrgMSP, StatusDisputed, ""},
+		{"submitter appeals rejection", StatusRejected, TransitionAppeal, WhistleblowersOrgMSP, StatusAppealed, ""},
+		{"legal completes review", StatusUnderReview, TransitionCompleteReview, LegalOrgMSP, StatusReviewed, ""},
+		{"legal reopens review", StatusReviewed, TransitionReopenReview, LegalOrgMSP, StatusUnderReview, ""},
+		{"legal reports mismatch after export", StatusExported, TransitionHashMismatch, LegalOrgMSP, StatusReverify, ""},
+		{"legal re-exports", StatusExported, TransitionExport, LegalOrgMSP, StatusExported, ""},
+		{"submitter withdraws before export", StatusReviewed, TransitionWithdraw, WhistleblowersOrgMSP, StatusWithdrawn, ""},
+		{"no withdrawal after export", StatusExported, TransitionWithdraw, WhistleblowersOrgMSP, "", "is not allowed from status EXPORTED"},
+		{"no review before verification", StatusSubmitted, TransitionStartReview, LegalOrgMSP, "", "is not allowed from status SUBMITTED"},
+		{"no transitions out of withdrawn", StatusWithdrawn, TransitionAttest, VerifierOrgMSP, "", "is not allowed from status WITHDRAWN"},
+		{"legal may not verify", StatusSubmitted, TransitionVerifyPass, LegalOrgMSP, "", "may not take action VERIFY_PASS"},
+		{"verifier may not export", StatusReviewed, TransitionExport, VerifierOrgMSP, "", "may not take action EXPORT"},
+		{"unknown organization", StatusSubmitted, TransitionAttest, "OtherOrgMSP", "", "may not take action ATTEST"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := newTestContext(newLedgerStub(), &testIdentity{mspID: tt.mspID, id: "caller"})
+			evidence := &Evidence{EvidenceID: "EVD1", Status: tt.status}
+
+			transition, err := checkTransition(ctx, evidence, tt.action)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if transition.To != tt.wantTo {
+					t.Fatalf("transition to %s, want %s", transition.To, tt.wantTo)
+				}
+				if evidence.Status != tt.status {
+					t.Fatalf("checkTransition changed status to %s", evidence.Status)
+				}
+				return
+			}
+
+			var transitionErr *TransitionError
+			if !errors.As(err, &transitionErr) {
+				t.Fatalf("error %v is not a TransitionError", err)
+			}
+			if !strings.HasPrefix(err.Error(), ErrCodeIllegalTransition) {
+				t.Fatalf("error %q lacks the %s prefix", err, ErrCodeIllegalTransition)
+			}
+			if !strings.Contains(transitionErr.Reason, tt.wantErr) {
+				t.Fatalf("reason %q does not contain %q", transitionErr.Reason, tt.wantErr)
+			}
+			if transitionErr.CallerMSP != tt.mspID || transitionErr.Status != tt.status {
+				t.Fatalf("error details %+v do not describe the attempt", transitionErr)
+			}
+		})
+	}
+}
+
+func TestCheckTransitionReportsAllowedActions(t *testing.T) {
+	ctx := newTestContext(newLedgerStub(), &testIdentity{mspID: WhistleblowersOrgMSP, id: "caller"})
+
+	_, err := checkTransition(ctx, &Evidence{EvidenceID: "EVD1", Status: StatusRejected}, TransitionVerifyPass)
+	var transitionErr *TransitionError
+	if !errors.As(err, &transitionErr) {
+		t.Fatalf("error %v is not a TransitionError", err)
+	}
+
+	want := []string{TransitionAppeal, TransitionWithdraw, TransitionSupersede}
+	if strings.Join(transitionErr.AllowedActions, ",") != strings.Join(want, ",") {
+		t.Fatalf("allowed actions %v, want %v", transitionErr.AllowedActions, want)
+	}
+}
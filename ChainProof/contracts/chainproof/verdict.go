@@ -0,0 +1,223 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// =============================================================================
+// ChainProof - Legal Verdicts
+// =============================================================================
+// A completed legal review carries one of a fixed set of verdicts. Each verdict
+// moves the submitter's trust score by an amount configured in the ledger
+// parameters. The reviewer's justification is kept in LegalPrivateCollection;
+// only its hash is recorded on the public evidence record. The justification
+// and a random client-generated salt arrive in the transient map, so neither
+// lands in the block, and the public hash covers salt||justification so a
+// short or guessable justification cannot be confirmed by hashing candidates.
+// Reopening a review reverses the trust score change its verdict applied; both
+// the verdict and the reversal stay in the custody log and the reputation
+// history.
+// =============================================================================
+
+// Transient map keys carrying a review justification (kept out of the transaction arguments)
+const (
+	TransientJustification     = "justification"
+	TransientJustificationSalt = "justificationSalt"
+)
+
+// minJustificationSaltBytes is the shortest salt accepted for a justification hash
+const minJustificationSaltBytes = 16
+
+// validVerdicts lists the accepted legal verdicts
+var validVerdicts = []string{VerdictSubstantiated, VerdictUnsubstantiated, VerdictInconclusive, VerdictOutOfScope}
+
+// validateVerdict rejects verdicts outside the enumeration
+func validateVerdict(verdict string) error {
+	for _, valid := range validVerdicts {
+		if verdict == valid {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid verdict %q: expected one of %s", verdict, strings.Join(validVerdicts, ", "))
+}
+
+// verdictReputationEffect returns the configured trust score change for a verdict
+func verdictReputationEffect(params *ChainProofParams, verdict string) int {
+	return params.VerdictReputationEffects[verdict]
+}
+
+// ReopenReview moves REVIEWED evidence back to UNDER_REVIEW and reverses the verdict's reputation effect
+func (c *LegalContract) ReopenReview(
+	ctx contractapi.TransactionContextInterface,
+	evidenceId string,
+	reason string,
+) error {
+	if err := RequireLegalOrg(ctx, RoleSupervisor); err != nil {
+		return err
+	}
+	if strings.TrimSpace(reason) == "" {
+		return fmt.Errorf("a reason is required to reopen a legal review")
+	}
+
+	evidence, err := getEvidence(ctx, evidenceId)
+	if err != nil {
+		return err
+	}
+	if _, err := applyTransition(ctx, evidence, TransitionReopenReview); err != nil {
+		return err
+	}
+
+	timestamp, err := getTxTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+
+	previousVerdict := evidence.Verdict
+	reversed, err := reverseVerdict(ctx, evidence, reason, timestamp)
+	if err != nil {
+		return err
+	}
+	evidence.ReviewReopenCount++
+	evidence.ReviewReopenedAt = timestamp
+
+	if err := putEvidence(ctx, evidence); err != nil {
+		return err
+	}
+
+	return appendCustodyLog(ctx, evidenceId, ActionReopenReview,
+		fmt.Sprintf("Legal review reopened. Verdict %s reversed (trust score %+d). Reason: %s", previousVerdict, reversed, reason), timestamp)
+}
+
+// GetReviewJustifications retrieves the private justifications behind an evidence item's verdicts (LegalOrg only)
+func (c *LegalContract) GetReviewJustifications(
+	ctx contractapi.TransactionContextInterface,
+	evidenceId string,
+) ([]*ReviewJustification, error) {
+	if err := RequireLegalOrg(ctx, RoleSupervisor, RoleAuditor); err != nil {
+		return nil, err
+	}
+
+	queryString, err := NewSelector("review_justification").Equals("evidenceId", evidenceId).Build()
+	if err != nil {
+		return nil, err
+	}
+	collection, err := orgCollection(ctx, OrgRoleLegal)
+	if err != nil {
+		return nil, err
+	}
+	resultsIterator, err := ctx.GetStub().GetPrivateDataQueryResult(collection, queryString)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query review justifications: %v", err)
+	}
+	defer resultsIterator.Close()
+
+	justifications := []*ReviewJustification{}
+	for resultsIterator.HasNext() {
+		queryResult, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		var justification ReviewJustification
+		if err := json.Unmarshal(queryResult.Value, &justification); err != nil {
+			return nil, err
+		}
+		justifications = append(justifications, &justification)
+	}
+
+	return justifications, nil
+}
+
+// reverseVerdict undoes the trust score change of the evidence's verdict and clears it
+func reverseVerdict(ctx contractapi.TransactionContextInterface, evidence *Evidence, reason string, timestamp int64) (int, error) {
+	reversed, err := applyReputationEvent(ctx, evidence.PublicKeyHash, reputationEvent{
+		EvidenceID: evidence.EvidenceID,
+		Cause:      ReputationCauseVerdictReversed,
+		Detail:     fmt.Sprintf("Legal verdict %s reversed: %s", evidence.Verdict, reason),
+		Weight:     -evidence.VerdictReputationDelta,
+		Reversal:   true,
+	}, timestamp)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reverse reputation: %v", err)
+	}
+
+	evidence.ReviewedAt = 0
+	evidence.Verdict = ""
+	evidence.VerdictReputationDelta = 0
+	evidence.JustificationHash = ""
+	return reversed, nil
+}
+
+// getTransientJustification reads a review justification and its salt from the transient map
+func getTransientJustification(ctx contractapi.TransactionContextInterface) (string, []byte, error) {
+	transient, err := ctx.GetStub().GetTransient()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read transient data: %v", err)
+	}
+	justification := string(transient[TransientJustification])
+	if strings.TrimSpace(justification) == "" {
+		return "", nil, fmt.Errorf("a justification is required to complete a legal review (transient key %q)", TransientJustification)
+	}
+	salt := transient[TransientJustificationSalt]
+	if len(salt) < minJustificationSaltBytes {
+		return "", nil, fmt.Errorf("transient key %q must carry a random salt of at least %d bytes",
+			TransientJustificationSalt, minJustificationSaltBytes)
+	}
+	return justification, salt, nil
+}
+
+// hashJustification returns the hex SHA256 of salt||justification
+func hashJustification(salt []byte, justification string) string {
+	hash := sha256.Sum256(append(append([]byte{}, salt...), justification...))
+	return hex.EncodeToString(hash[:])
+}
+
+// putReviewJustification stores the justification and its salt privately and returns the salted hash
+func putReviewJustification(
+	ctx contractapi.TransactionContextInterface,
+	evidenceId string,
+	verdict string,
+	justification string,
+	salt []byte,
+	timestamp int64,
+) (string, error) {
+	callerOrg, _ := GetClientOrgID(ctx)
+	reviewerId, err := GetClientFingerprint(ctx)
+	if err != nil {
+		return "", err
+	}
+	txId := ctx.GetStub().GetTxID()
+
+	record := ReviewJustification{
+		DocType:          "review_justification",
+		EvidenceID:       evidenceId,
+		Verdict:          verdict,
+		Justification:    justification,
+		Salt:             hex.EncodeToString(salt),
+		ReviewerID:       reviewerId,
+		LegalReviewerOrg: callerOrg,
+		TxID:             txId,
+		CreatedAt:        timestamp,
+	}
+	recordJSON, err := json.Marshal(record)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal review justification: %v", err)
+	}
+
+	key := fmt.Sprintf("justification_%s_%s", evidenceId, txId)
+	collection, err := orgCollection(ctx, OrgRoleLegal)
+	if err != nil {
+		return "", err
+	}
+	if err := writePrivateData(ctx, collection, key, recordJSON); err != nil {
+		return "", err
+	}
+
+	return hashJustification(salt, justification), nil
+}
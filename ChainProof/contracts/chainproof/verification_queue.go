@@ -0,0 +1,275 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// =============================================================================
+// ChainProof - Verification Queue
+// =============================================================================
+// Ranks the evidence awaiting verifier attestations so verifiers work on the
+// most important items first. Each item gets four component scores (0-100),
+// weighted into a priority of 0-100:
+//   TRUST       submitter's current trust score (read from the reputation
+//               record; the key itself is never returned)
+//   SEVERITY    fixed severity of the evidence category
+//   AGE         share of the verification deadline already used
+//   ASSIGNMENT  assigned to the caller, to nobody, or to another verifier
+// CouchDB cannot sort by a computed score, so the whole queue is ranked on
+// every call and the bookmark is an offset into the ranking.
+// =============================================================================
+
+// Verification queue factor weights (percent, summing to 100)
+const (
+	queueWeightTrust      = 30
+	queueWeightSeverity   = 30
+	queueWeightAge        = 25
+	queueWeightAssignment = 15
+)
+
+// Assignment scores
+const (
+	queueAssignedToCaller = 100
+	queueUnassigned       = 60
+	queueAssignedToOther  = 0
+)
+
+// categorySeverity scores each built-in category; other categories score defaultCategorySeverity
+var categorySeverity = map[string]int{
+	CategorySafety:         100,
+	CategoryAbuse:          90,
+	CategoryCorruption:     80,
+	CategoryFinancialFraud: 70,
+	CategoryHarassment:     70,
+	CategoryEnvironmental:  70,
+	CategoryOther:          40,
+}
+
+// defaultCategorySeverity scores uncategorized evidence and categories added by governance
+const defaultCategorySeverity = 50
+
+// GetVerificationQueue returns evidence awaiting verification, highest priority first
+// bookmark is "" for the first page, then the bookmark of the previous page.
+func (c *VerifierContract) GetVerificationQueue(
+	ctx contractapi.TransactionContextInterface,
+	pageSize int32,
+	bookmark string,
+) (*VerificationQueueResult, error) {
+	if err := RequireVerifierOrg(ctx, RoleReviewer, RoleSupervisor); err != nil {
+		return nil, err
+	}
+
+	if pageSize < 1 {
+		return nil, fmt.Errorf("pageSize must be at least 1, got %d", pageSize)
+	}
+	offset := 0
+	if bookmark != "" {
+		var err error
+		if offset, err = strconv.Atoi(bookmark); err != nil || offset < 0 {
+			return nil, fmt.Errorf("invalid bookmark %q", bookmark)
+		}
+	}
+
+	params, err := getParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	timestamp, err := getTxTimestamp(ctx)
+	if err != nil {
+		return nil, err
+	}
+	caller, err := GetClientFingerprint(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := getAttestableEvidence(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// One reputation read per submitter key; -1 marks keys without a record (and legacy evidence without a key)
+	trustScores := map[string]int{"": -1}
+	items := []*VerificationQueueItem{}
+	for _, evidence := range pending {
+		trust, cached := trustScores[evidence.PublicKeyHash]
+		if !cached {
+			if trust, err = submitterTrustScore(ctx, evidence.PublicKeyHash, params, timestamp); err != nil {
+				return nil, err
+			}
+			trustScores[evidence.PublicKeyHash] = trust
+		}
+		items = append(items, rankQueueItem(evidence, trust, caller, params, timestamp))
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Priority != items[j].Priority {
+			return items[i].Priority > items[j].Priority
+		}
+		if items[i].SubmittedAt != items[j].SubmittedAt {
+			return items[i].SubmittedAt < items[j].SubmittedAt
+		}
+		return items[i].EvidenceID < items[j].EvidenceID
+	})
+
+	result := &VerificationQueueResult{
+		CheckedAt:    timestamp,
+		TotalPending: len(items),
+		Records:      []*VerificationQueueItem{},
+	}
+	if offset < len(items) {
+		end := min(len(items), offset+int(pageSize))
+		result.Records = items[offset:end]
+		if end < len(items) {
+			result.Bookmark = strconv.Itoa(end)
+		}
+	}
+	result.FetchedRecordsCount = len(result.Records)
+	return result, nil
+}
+
+// rankQueueItem scores one pending evidence item and explains each factor (trust -1 = unknown)
+func rankQueueItem(
+	evidence *Evidence,
+	trust int,
+	caller string,
+	params *ChainProofParams,
+	now int64,
+) *VerificationQueueItem {
+	trustReason := fmt.Sprintf("submitter trust score %d", trust)
+	if trust < 0 {
+		trust = params.InitialTrustScore
+		trustReason = fmt.Sprintf("no submitter reputation yet; initial score %d", trust)
+	}
+
+	severity, ok := categorySeverity[evidence.Category]
+	if !ok {
+		severity = defaultCategorySeverity
+	}
+	severityReason := fmt.Sprintf("category %q", evidence.Category)
+	if evidence.Category == "" {
+		severityReason = "no category"
+	}
+
+	// Age runs against the earlier of the SLA deadline and an assigned due date
+	deadline := evidence.SubmittedAt + params.VerificationSLASeconds
+	if evidence.VerificationDueAt > 0 && evidence.VerificationDueAt < deadline {
+		deadline = evidence.VerificationDueAt
+	}
+	elapsed := now - evidence.SubmittedAt
+	age := 100
+	if window := deadline - evidence.SubmittedAt; window > 0 && elapsed < window {
+		age = int(max64(0, elapsed) * 100 / window)
+	}
+	ageReason := fmt.Sprintf("%d%% of the verification deadline used (due %d)", age, deadline)
+	if now > deadline {
+		ageReason = fmt.Sprintf("overdue by %ds (due %d)", now-deadline, deadline)
+	}
+
+	var assignment int
+	var assignmentReason string
+	switch evidence.VerificationAssignee {
+	case "":
+		assignment, assignmentReason = queueUnassigned, "unassigned"
+	case caller:
+		assignment, assignmentReason = queueAssignedToCaller, "assigned to you"
+	default:
+		assignment, assignmentReason = queueAssignedToOther, "assigned to another verifier"
+	}
+
+	factors := []*QueuePriorityFactor{
+		newQueueFactor(QueueFactorTrust, trust, queueWeightTrust, trustReason),
+		newQueueFactor(QueueFactorSeverity, severity, queueWeightSeverity, severityReason),
+		newQueueFactor(QueueFactorAge, age, queueWeightAge, ageReason),
+		newQueueFactor(QueueFactorAssignment, assignment, queueWeightAssignment, assignmentReason),
+	}
+	priority := 0
+	for _, factor := range factors {
+		priority += factor.Points
+	}
+
+	return &VerificationQueueItem{
+		EvidenceID:        evidence.EvidenceID,
+		Status:            evidence.Status,
+		Category:          evidence.Category,
+		FileType:          evidence.FileType,
+		SubmittedAt:       evidence.SubmittedAt,
+		VerificationRound: evidence.VerificationRound,
+		Assignee:          evidence.VerificationAssignee,
+		VerificationDueAt: evidence.VerificationDueAt,
+		Priority:          priority,
+		Factors:           factors,
+	}
+}
+
+// newQueueFactor weights a component score
+func newQueueFactor(factor string, score int, weight int, reason string) *QueuePriorityFactor {
+	return &QueuePriorityFactor{
+		Factor: factor,
+		Score:  score,
+		Weight: weight,
+		Points: score * weight / 100,
+		Reason: reason,
+	}
+}
+
+// submitterTrustScore returns a key's current trust score, or -1 when it has no reputation record
+func submitterTrustScore(
+	ctx contractapi.TransactionContextInterface,
+	publicKeyHash string,
+	params *ChainProofParams,
+	now int64,
+) (int, error) {
+	reputation, err := findReputation(ctx, publicKeyHash)
+	if err != nil {
+		return 0, err
+	}
+	if reputation == nil {
+		return -1, nil
+	}
+	return currentTrustScore(reputation, params, now)
+}
+
+// attestableStatuses returns the statuses in which verifiers can attest, from the state machine
+func attestableStatuses() []string {
+	statuses := []string{}
+	for _, t := range evidenceTransitions {
+		if t.Action == TransitionAttest && !containsString(statuses, t.From) {
+			statuses = append(statuses, t.From)
+		}
+	}
+	return statuses
+}
+
+// getAttestableEvidence returns every evidence item awaiting verifier attestations
+func getAttestableEvidence(ctx contractapi.TransactionContextInterface) ([]*Evidence, error) {
+	queryString, err := NewSelector("evidence").In("status", attestableStatuses()).Build()
+	if err != nil {
+		return nil, err
+	}
+	resultsIterator, err := ctx.GetStub().GetQueryResult(queryString)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending evidence: %v", err)
+	}
+	defer resultsIterator.Close()
+
+	records := []*Evidence{}
+	for resultsIterator.HasNext() {
+		queryResult, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		var evidence Evidence
+		if err := json.Unmarshal(queryResult.Value, &evidence); err != nil {
+			return nil, err
+		}
+		records = append(records, &evidence)
+	}
+	return records, nil
+}
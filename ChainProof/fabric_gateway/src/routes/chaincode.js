@@ -57,12 +57,12 @@ router.post('/org/switch', async (req, res, next) => {
  */
 router.post('/evidence/submit', async (req, res, next) => {
     try {
-        const { evidenceId, ipfsCid, fileHash, fileType, fileSize, category, description, publicKeyHash, signature } = req.body;
+        const { evidenceId, ipfsCid, fileHash, fileType, fileSize, category, description, publicKeyHash, publicKey, signature, supersedesEvidenceId } = req.body;
 
-        if (!evidenceId || !ipfsCid || !fileHash || !publicKeyHash || !signature) {
+        if (!evidenceId || !ipfsCid || !fileHash || !publicKeyHash || !publicKey || !signature) {
             return res.status(400).json({
                 success: false,
-                error: 'Missing required fields: evidenceId, ipfsCid, fileHash, publicKeyHash, signature'
+                error: 'Missing required fields: evidenceId, ipfsCid, fileHash, publicKeyHash, publicKey, signature'
             });
         }
 
@@ -74,7 +74,9 @@ router.post('/evidence/submit', async (req, res, next) => {
             category || 'other',
             description,
             publicKeyHash,
-            signature
+            publicKey,
+            signature,
+            supersedesEvidenceId
         );
 
         res.status(201).json({ success: true, data: result });
@@ -125,12 +127,32 @@ router.post('/evidence/:evidenceId/anchor', async (req, res, next) => {
 });
 
 /**
- * GET /api/fabric/notifications/:publicKeyHash
- * Get notifications for whistleblower
+ * Extract a key ownership proof (publicKey, challengeTimestamp, signature)
+ * The signature covers chainproof:<action>:<publicKeyHash>:<subject>:<challengeTimestamp>
  */
-router.get('/notifications/:publicKeyHash', async (req, res, next) => {
+function getKeyProof(source) {
+    const { publicKey, challengeTimestamp, signature } = source;
+    if (!publicKey || !challengeTimestamp || !signature) {
+        return null;
+    }
+    return { publicKey, challengeTimestamp, signature };
+}
+
+/**
+ * GET /api/fabric/notifications?publicKey=&challengeTimestamp=&signature=
+ * Get notifications for whistleblower (requires key ownership proof)
+ */
+router.get('/notifications', async (req, res, next) => {
     try {
-        const result = await fabric.getNotifications(req.params.publicKeyHash);
+        const proof = getKeyProof(req.query);
+        if (!proof) {
+            return res.status(400).json({
+                success: false,
+                error: 'publicKey, challengeTimestamp and signature are required'
+            });
+        }
+
+        const result = await fabric.getNotifications(proof);
         res.json({ success: true, data: result || [] });
     } catch (error) {
         next(error);
@@ -139,11 +161,19 @@ router.get('/notifications/:publicKeyHash', async (req, res, next) => {
 
 /**
  * POST /api/fabric/notifications/:notificationId/read
- * Mark notification as read
+ * Mark notification as read (requires key ownership proof in body)
  */
 router.post('/notifications/:notificationId/read', async (req, res, next) => {
     try {
-        await fabric.markNotificationRead(req.params.notificationId);
+        const proof = getKeyProof(req.body);
+        if (!proof) {
+            return res.status(400).json({
+                success: false,
+                error: 'publicKey, challengeTimestamp and signature are required'
+            });
+        }
+
+        await fabric.markNotificationRead(req.params.notificationId, proof);
         res.json({ success: true });
     } catch (error) {
         next(error);
@@ -151,12 +181,20 @@ router.post('/notifications/:notificationId/read', async (req, res, next) => {
 });
 
 /**
- * GET /api/fabric/reputation/:publicKeyHash
- * Get reputation score
+ * GET /api/fabric/reputation?publicKey=&challengeTimestamp=&signature=
+ * Get reputation score (requires key ownership proof)
  */
-router.get('/reputation/:publicKeyHash', async (req, res, next) => {
+router.get('/reputation', async (req, res, next) => {
     try {
-        const result = await fabric.getReputation(req.params.publicKeyHash);
+        const proof = getKeyProof(req.query);
+        if (!proof) {
+            return res.status(400).json({
+                success: false,
+                error: 'publicKey, challengeTimestamp and signature are required'
+            });
+        }
+
+        const result = await fabric.getReputation(proof);
         res.json({ success: true, data: result });
     } catch (error) {
         next(error);
@@ -242,9 +280,9 @@ router.get('/verify/:evidenceId/notes', async (req, res, next) => {
  */
 router.post('/legal/:evidenceId/review', async (req, res, next) => {
     try {
-        const { complete, verdict } = req.body;
+        const { complete, verdict, justification } = req.body;
         logger.info(`Legal review: ${req.params.evidenceId}, complete: ${complete}, verdict: ${verdict}`);
-        const result = await fabric.reviewEvidence(req.params.evidenceId, complete || false, verdict);
+        const result = await fabric.reviewEvidence(req.params.evidenceId, complete || false, verdict, justification);
         res.json({ success: true, data: result });
     } catch (error) {
         next(error);
@@ -284,12 +322,15 @@ router.get('/legal/:evidenceId/comments', async (req, res, next) => {
 
 /**
  * POST /api/fabric/legal/:evidenceId/export
- * Export evidence for court
+ * Request a court export
+ * The gateway never approves exports: a second LegalOrg member submits ApproveExport
+ * from their own client with their own credential.
  */
 router.post('/legal/:evidenceId/export', async (req, res, next) => {
     try {
-        logger.info(`Exporting evidence: ${req.params.evidenceId}`);
-        const result = await fabric.exportEvidence(req.params.evidenceId);
+        const { reason } = req.body;
+        logger.info(`Requesting export: ${req.params.evidenceId}`);
+        const result = await fabric.requestExport(req.params.evidenceId, reason);
         res.json({ success: true, data: result });
     } catch (error) {
         next(error);
@@ -336,4 +377,50 @@ router.get('/query/category/:category', async (req, res, next) => {
     }
 });
 
+/**
+ * GET /api/fabric/query/exports/:evidenceId/requests
+ * List export requests and their approval state
+ */
+router.get('/query/exports/:evidenceId/requests', async (req, res, next) => {
+    try {
+        const result = await fabric.getExportRequests(req.params.evidenceId);
+        res.json({ success: true, data: result });
+    } catch (error) {
+        next(error);
+    }
+});
+
+/**
+ * GET /api/fabric/query/exports/:evidenceId
+ * List every stored court export of an evidence item
+ */
+router.get('/query/exports/:evidenceId', async (req, res, next) => {
+    try {
+        const result = await fabric.getExportRecords(req.params.evidenceId);
+        res.json({ success: true, data: result });
+    } catch (error) {
+        next(error);
+    }
+});
+
+/**
+ * POST /api/fabric/query/exports/verify
+ * Verify a handed-over export JSON against the ledger
+ */
+router.post('/query/exports/verify', async (req, res, next) => {
+    try {
+        const { exportRecord } = req.body;
+        if (!exportRecord) {
+            return res.status(400).json({
+                success: false,
+                error: 'exportRecord is required'
+            });
+        }
+        const result = await fabric.verifyExport(exportRecord);
+        res.json({ success: true, data: result });
+    } catch (error) {
+        next(error);
+    }
+});
+
 module.exports = router;
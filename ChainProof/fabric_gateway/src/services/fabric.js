@@ -6,6 +6,7 @@
  */
 
 const { Wallets, Gateway } = require('fabric-network');
+const crypto = require('crypto');
 const fs = require('fs');
 const path = require('path');
 const config = require('../config');
@@ -236,6 +237,24 @@ async function submitTransaction(contractName, functionName, ...args) {
     return result.length > 0 ? JSON.parse(result.toString()) : null;
 }
 
+/**
+ * Submit transaction with private inputs in the transient map (kept out of the block)
+ */
+async function submitTransactionWithTransient(contractName, functionName, transientData, ...args) {
+    if (!contracts[contractName]) {
+        throw new Error(`Contract not found: ${contractName}`);
+    }
+
+    logger.info(`Submitting: ${contractName}:${functionName}(${args.join(', ')}) with transient ${Object.keys(transientData).join(', ')}`);
+
+    const result = await contracts[contractName]
+        .createTransaction(functionName)
+        .setTransient(transientData)
+        .submit(...args);
+
+    return result.length > 0 ? JSON.parse(result.toString()) : null;
+}
+
 /**
  * Evaluate transaction (query)
  */
@@ -255,7 +274,7 @@ async function evaluateTransaction(contractName, functionName, ...args) {
 // WHISTLEBLOWER CONTRACT FUNCTIONS
 // ============================================================
 
-async function submitEvidence(evidenceId, ipfsCid, fileHash, fileType, fileSize, category, description, publicKeyHash, signature) {
+async function submitEvidence(evidenceId, ipfsCid, fileHash, fileType, fileSize, category, description, publicKeyHash, publicKey, signature, supersedesEvidenceId) {
     // Ensure we are submitting as WhistleblowersOrg (required by chaincode policy)
     if (getCurrentOrg() !== 'WhistleblowersOrg') {
         logger.info(`Auto-switching to WhistleblowersOrg for evidence submission...`);
@@ -263,31 +282,35 @@ async function submitEvidence(evidenceId, ipfsCid, fileHash, fileType, fileSize,
     }
 
     return await submitTransaction('whistleblower', 'SubmitEvidence',
-        evidenceId, ipfsCid, fileHash, fileType, String(fileSize), category, description || '', publicKeyHash, signature);
+        evidenceId, ipfsCid, fileHash, fileType, String(fileSize), category, description || '', publicKeyHash, publicKey, signature,
+        supersedesEvidenceId || '');
 }
 
-async function getNotifications(publicKeyHash) {
+async function getNotifications({ publicKey, challengeTimestamp, signature }) {
     if (getCurrentOrg() !== 'WhistleblowersOrg') {
         logger.info(`Auto-switching to WhistleblowersOrg for notifications...`);
         await switchOrg('WhistleblowersOrg');
     }
-    return await evaluateTransaction('whistleblower', 'GetNotifications', publicKeyHash);
+    return await evaluateTransaction('whistleblower', 'GetNotifications',
+        publicKey, String(challengeTimestamp), signature);
 }
 
-async function getReputation(publicKeyHash) {
+async function getReputation({ publicKey, challengeTimestamp, signature }) {
     if (getCurrentOrg() !== 'WhistleblowersOrg') {
         logger.info(`Auto-switching to WhistleblowersOrg for reputation...`);
         await switchOrg('WhistleblowersOrg');
     }
-    return await evaluateTransaction('whistleblower', 'GetReputation', publicKeyHash);
+    return await evaluateTransaction('whistleblower', 'GetReputation',
+        publicKey, String(challengeTimestamp), signature);
 }
 
-async function markNotificationRead(notificationId) {
+async function markNotificationRead(notificationId, { publicKey, challengeTimestamp, signature }) {
     if (getCurrentOrg() !== 'WhistleblowersOrg') {
         logger.info(`Auto-switching to WhistleblowersOrg for marking as read...`);
         await switchOrg('WhistleblowersOrg');
     }
-    return await submitTransaction('whistleblower', 'MarkNotificationRead', notificationId);
+    return await submitTransaction('whistleblower', 'MarkNotificationRead',
+        notificationId, publicKey, String(challengeTimestamp), signature);
 }
 
 async function updatePolygonAnchor(evidenceId, polygonTxHash) {
@@ -328,12 +351,19 @@ async function getVerificationNotes(evidenceId) {
 // LEGAL CONTRACT FUNCTIONS
 // ============================================================
 
-async function reviewEvidence(evidenceId, complete, verdict) {
+async function reviewEvidence(evidenceId, complete, verdict, justification) {
     if (getCurrentOrg() !== 'LegalOrg') {
         logger.info(`Auto-switching to LegalOrg for review...`);
         await switchOrg('LegalOrg');
     }
-    return await submitTransaction('legal', 'ReviewEvidence', evidenceId, String(complete), verdict || 'STAY');
+    if (!complete) {
+        return await submitTransaction('legal', 'ReviewEvidence', evidenceId, 'false', '');
+    }
+    // The justification and a fresh random salt stay out of the block; only the salted hash is public
+    return await submitTransactionWithTransient('legal', 'ReviewEvidence', {
+        justification: Buffer.from(justification || ''),
+        justificationSalt: crypto.randomBytes(32)
+    }, evidenceId, 'true', verdict || '');
 }
 
 async function addLegalComment(evidenceId, commentId, content, courtReadiness, recommendation) {
@@ -349,12 +379,12 @@ async function getLegalComments(evidenceId) {
     return await evaluateTransaction('legal', 'GetLegalComments', evidenceId);
 }
 
-async function exportEvidence(evidenceId) {
+async function requestExport(evidenceId, reason) {
     if (getCurrentOrg() !== 'LegalOrg') {
-        logger.info(`Auto-switching to LegalOrg for export...`);
+        logger.info(`Auto-switching to LegalOrg for export request...`);
         await switchOrg('LegalOrg');
     }
-    return await submitTransaction('legal', 'ExportEvidence', evidenceId);
+    return await submitTransaction('legal', 'RequestExport', evidenceId, reason || '');
 }
 
 // ============================================================
@@ -377,6 +407,19 @@ async function queryEvidenceByCategory(category, pageSize, bookmark) {
     return await evaluateTransaction('query', 'QueryEvidenceByCategory', category, String(pageSize), bookmark || '');
 }
 
+async function getExportRequests(evidenceId) {
+    return await evaluateTransaction('query', 'GetExportRequests', evidenceId);
+}
+
+async function getExportRecords(evidenceId) {
+    return await evaluateTransaction('query', 'GetExportRecords', evidenceId);
+}
+
+async function verifyExport(exportRecord) {
+    const exportJson = typeof exportRecord === 'string' ? exportRecord : JSON.stringify(exportRecord);
+    return await evaluateTransaction('query', 'VerifyExport', exportJson);
+}
+
 module.exports = {
     initializeGateway,
     switchOrg,
@@ -384,6 +427,7 @@ module.exports = {
     getCurrentOrg,
     loadIdentityFromWallet,
     submitTransaction,
+    submitTransactionWithTransient,
     evaluateTransaction,
     // Whistleblower
     submitEvidence,
@@ -399,10 +443,13 @@ module.exports = {
     reviewEvidence,
     addLegalComment,
     getLegalComments,
-    exportEvidence,
+    requestExport,
     // Query
     getEvidence,
     getEvidenceHistory,
     queryEvidenceByStatus,
-    queryEvidenceByCategory
+    queryEvidenceByCategory,
+    getExportRequests,
+    getExportRecords,
+    verifyExport
 };
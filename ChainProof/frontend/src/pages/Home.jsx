@@ -12,6 +12,7 @@ import {
 function Home({ setPage }) {
     const [keyState, setKeyState] = useState(hasSessionKey() ? 'loaded' : 'none') // none, loaded
     const [publicKeyHash, setPublicKeyHash] = useState(getSessionKey()?.publicKeyHash || '')
+    const [legacyHash, setLegacyHash] = useState(getSessionKey()?.legacyPublicKeyHash || null)
     const [error, setError] = useState('')
     const [loading, setLoading] = useState(false)
     const fileInputRef = useRef()
@@ -31,6 +32,7 @@ function Home({ setPage }) {
             setSessionKey(imported)
 
             setPublicKeyHash(imported.publicKeyHash)
+            setLegacyHash(null)
             setKeyState('loaded')
         } catch (err) {
             setError(err.message)
@@ -50,6 +52,7 @@ function Home({ setPage }) {
             const imported = await importFromBackupFile(file)
             setSessionKey(imported)
             setPublicKeyHash(imported.publicKeyHash)
+            setLegacyHash(imported.legacyPublicKeyHash)
             setKeyState('loaded')
         } catch (err) {
             setError(err.message)
@@ -142,6 +145,15 @@ function Home({ setPage }) {
                             <div style={{ color: 'var(--accent-secondary)' }}>{publicKeyHash}</div>
                         </div>
 
+                        {legacyHash && (
+                            <p style={{ color: 'var(--warning)', fontSize: '0.85rem' }}>
+                                ⚠️ This backup was created with an older version that identified you as{' '}
+                                <code>{legacyHash.substring(0, 16)}...</code>. Your identity is now derived
+                                from the public key the ledger verifies. Evidence submitted under the old ID
+                                cannot be tracked with this key.
+                            </p>
+                        )}
+
                         <p style={{ color: 'var(--text-muted)', fontSize: '0.85rem' }}>
                             ⚠️ This key is only in memory. It will be gone when you close this tab.
                             Make sure you have your backup file saved!
@@ -60,10 +60,11 @@ function Submit() {
             formData.append('category', category || 'other')
             formData.append('description', description)
             formData.append('publicKeyHash', sessionKey.publicKeyHash)
+            formData.append('publicKey', sessionKey.publicKeySpki)
             formData.append('signature', signature)
 
-            // Submit to backend + blockchain
-            const response = await submitEvidenceFull(formData)
+            // Submit to backend + blockchain (the ledger signature is made over the stripped file's hash)
+            const response = await submitEvidenceFull(formData, sessionKey)
             setResult(response.data)
 
         } catch (err) {
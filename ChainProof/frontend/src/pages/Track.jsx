@@ -28,8 +28,8 @@ function Track() {
         setLoading(true)
         try {
             const [notifRes, repRes] = await Promise.all([
-                getNotifications(sessionKey.publicKeyHash),
-                getReputation(sessionKey.publicKeyHash)
+                getNotifications(sessionKey),
+                getReputation(sessionKey)
             ])
             const notifData = notifRes.data
             setNotifications(Array.isArray(notifData) ? notifData : (notifData?.notifications || []))
@@ -1,6 +1,14 @@
 import { useState } from 'react'
 import { reviewEvidence, addLegalComment, exportEvidence, getEvidenceHistory, getEvidence, fetchFileBlob, computeHash } from '../../services/api'
 
+// Legal verdicts accepted by the chaincode; reputation effects are configured on-ledger
+const VERDICTS = [
+    { value: 'SUBSTANTIATED', label: 'Substantiated', color: 'var(--success)' },
+    { value: 'UNSUBSTANTIATED', label: 'Unsubstantiated', color: 'var(--error)' },
+    { value: 'INCONCLUSIVE', label: 'Inconclusive', color: 'var(--text-secondary)' },
+    { value: 'OUT_OF_SCOPE', label: 'Out of Scope', color: 'var(--text-muted)' }
+]
+
 function LegalReview({ evidenceData, setPage }) {
     const [evidence, setEvidence] = useState(evidenceData)
     const [comment, setComment] = useState('')
@@ -11,7 +19,8 @@ function LegalReview({ evidenceData, setPage }) {
     const [history, setHistory] = useState(null)
     const [integrityStatus, setIntegrityStatus] = useState(null) // 'loading', 'match', 'mismatch'
     const [auditLog, setAuditLog] = useState([])
-    const [verdict, setVerdict] = useState('TRUE')
+    const [verdict, setVerdict] = useState('SUBSTANTIATED')
+    const [justification, setJustification] = useState('')
 
     const refreshEvidence = async () => {
         try {
@@ -61,7 +70,7 @@ function LegalReview({ evidenceData, setPage }) {
         setError(null)
 
         try {
-            await reviewEvidence(evidence.evidenceId, true, verdict)
+            await reviewEvidence(evidence.evidenceId, true, verdict, justification)
             await refreshEvidence()
             setResult({ complete: true })
             alert(`Review complete! Verdict: ${verdict}. Whistleblower reputation updated.`)
@@ -76,11 +85,11 @@ function LegalReview({ evidenceData, setPage }) {
         setSubmitting(true)
         setError(null)
         try {
-            await exportEvidence(evidence.evidenceId)
-            // Trigger PDF download from backend
-            window.open(`http://localhost:4000/api/legal/report/${evidence.evidenceId}`, '_blank')
+            // Four-eyes rule: a second legal member approves the request from their own client
+            const res = await exportEvidence(evidence.evidenceId, 'Court export')
+            if (!res.success) throw new Error(res.error || 'Export request failed')
             await refreshEvidence()
-            alert('Court report generated and downloaded.')
+            alert(`Export requested (${res.data?.requestId}). A second legal reviewer must approve it with their own credentials before the court report is issued.`)
         } catch (err) {
             setError(err.message)
         } finally {
@@ -88,6 +97,10 @@ function LegalReview({ evidenceData, setPage }) {
         }
     }
 
+    const handleDownloadReport = () => {
+        window.open(`http://localhost:4000/api/legal/report/${evidence.evidenceId}`, '_blank')
+    }
+
     const handleViewAndVerify = async () => {
         setIntegrityStatus('loading')
         setError(null)
@@ -285,50 +298,40 @@ function LegalReview({ evidenceData, setPage }) {
                     padding: '1rem',
                     background: 'var(--bg-secondary)',
                     borderRadius: '8px',
-                    borderLeft: `4px solid ${verdict === 'TRUE' ? 'var(--success)' : verdict === 'FALSE' ? 'var(--error)' : 'var(--text-muted)'}`
+                    borderLeft: `4px solid ${VERDICTS.find(option => option.value === verdict).color}`
                 }}>
                     <label style={{ color: 'var(--text-secondary)', display: 'block', marginBottom: '0.5rem', fontWeight: 'bold' }}>
                         ⚖️ Final Legal Verdict (Impacts Reputation)
                     </label>
                     <div style={{ display: 'flex', gap: '1.5rem', flexWrap: 'wrap' }}>
-                        <label style={{ display: 'flex', alignItems: 'center', gap: '0.5rem', cursor: 'pointer' }}>
-                            <input
-                                type="radio"
-                                name="verdict"
-                                value="TRUE"
-                                checked={verdict === 'TRUE'}
-                                onChange={() => setVerdict('TRUE')}
-                            />
-                            <span style={{ color: 'var(--success)', fontWeight: '500' }}>True Evidence (+3)</span>
-                        </label>
-                        <label style={{ display: 'flex', alignItems: 'center', gap: '0.5rem', cursor: 'pointer' }}>
-                            <input
-                                type="radio"
-                                name="verdict"
-                                value="FALSE"
-                                checked={verdict === 'FALSE'}
-                                onChange={() => setVerdict('FALSE')}
-                            />
-                            <span style={{ color: 'var(--error)', fontWeight: '500' }}>False/Malicious (-3)</span>
-                        </label>
-                        <label style={{ display: 'flex', alignItems: 'center', gap: '0.5rem', cursor: 'pointer' }}>
-                            <input
-                                type="radio"
-                                name="verdict"
-                                value="STAY"
-                                checked={verdict === 'STAY'}
-                                onChange={() => setVerdict('STAY')}
-                            />
-                            <span style={{ color: 'var(--text-secondary)' }}>Stay (No impact)</span>
-                        </label>
+                        {VERDICTS.map(option => (
+                            <label key={option.value} style={{ display: 'flex', alignItems: 'center', gap: '0.5rem', cursor: 'pointer' }}>
+                                <input
+                                    type="radio"
+                                    name="verdict"
+                                    value={option.value}
+                                    checked={verdict === option.value}
+                                    onChange={() => setVerdict(option.value)}
+                                />
+                                <span style={{ color: option.color, fontWeight: '500' }}>{option.label}</span>
+                            </label>
+                        ))}
                     </div>
+                    <textarea
+                        className="form-input"
+                        rows={3}
+                        placeholder="Justification for the verdict (required, stored privately)"
+                        value={justification}
+                        onChange={(e) => setJustification(e.target.value)}
+                        style={{ width: '100%', marginTop: '0.75rem' }}
+                    />
                 </div>
 
                 <div style={{ display: 'flex', gap: '1rem', flexWrap: 'wrap' }}>
                     <button
                         className="btn btn-primary"
                         onClick={handleMarkComplete}
-                        disabled={submitting}
+                        disabled={submitting || !justification.trim()}
                         style={{ flex: 1 }}
                     >
                         ✅ Mark Review Complete
@@ -336,7 +339,7 @@ function LegalReview({ evidenceData, setPage }) {
                     <button
                         className="btn btn-secondary"
                         onClick={handleExport}
-                        disabled={submitting || (evidence.status !== 'REVIEWED' && evidence.status !== 'EXPORTED')}
+                        disabled={submitting || !!evidence.exportRequestId || (evidence.status !== 'REVIEWED' && evidence.status !== 'EXPORTED')}
                         style={{
                             flex: 1,
                             background: (evidence.status === 'REVIEWED' || evidence.status === 'EXPORTED') ? 'var(--accent-secondary)' : 'var(--bg-secondary)',
@@ -345,8 +348,17 @@ function LegalReview({ evidenceData, setPage }) {
                         }}
                         title={evidence.status !== 'REVIEWED' ? "Must mark review as complete before exporting" : ""}
                     >
-                        📄 Export Court Report
+                        📄 {evidence.exportRequestId ? 'Awaiting Second Legal Approver' : 'Request Court Export'}
                     </button>
+                    {evidence.status === 'EXPORTED' && (
+                        <button
+                            className="btn btn-secondary"
+                            onClick={handleDownloadReport}
+                            style={{ flex: 1 }}
+                        >
+                            ⬇️ Download Court Report
+                        </button>
+                    )}
                 </div>
             </div>
         </div>
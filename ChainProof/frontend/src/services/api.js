@@ -6,6 +6,15 @@
  * - Fabric Gateway (port 5000): Chaincode transactions
  */
 
+import {
+    signData,
+    buildSubmissionMessage,
+    signKeyChallenge,
+    CHALLENGE_GET_NOTIFICATIONS,
+    CHALLENGE_MARK_NOTIFICATION_READ,
+    CHALLENGE_GET_REPUTATION
+} from './crypto';
+
 const BACKEND_URL = import.meta.env.VITE_API_URL || '';
 const FABRIC_URL = import.meta.env.VITE_FABRIC_URL || 'http://localhost:5000';
 
@@ -145,28 +154,48 @@ export async function getEvidenceHistory(evidenceId) {
 }
 
 /**
- * Get notifications from blockchain
+ * Build the query string carrying a key ownership proof
+ */
+function keyProofQuery(proof) {
+    return new URLSearchParams({
+        publicKey: proof.publicKey,
+        challengeTimestamp: String(proof.challengeTimestamp),
+        signature: proof.signature
+    }).toString();
+}
+
+/**
+ * Get notifications from blockchain (signs a GET_NOTIFICATIONS challenge)
+ * @param {Object} sessionKey - Imported key from crypto.importPrivateKey
  */
-export async function getNotifications(publicKeyHash) {
-    const response = await fetch(`${FABRIC_URL}/api/fabric/notifications/${publicKeyHash}`);
+export async function getNotifications(sessionKey) {
+    const proof = await signKeyChallenge(sessionKey, CHALLENGE_GET_NOTIFICATIONS);
+    const response = await fetch(`${FABRIC_URL}/api/fabric/notifications?${keyProofQuery(proof)}`);
     return response.json();
 }
 
 /**
- * Mark notification as read
+ * Mark notification as read (signs a MARK_NOTIFICATION_READ challenge scoped to the notification)
+ * @param {string} notificationId
+ * @param {Object} sessionKey - Imported key from crypto.importPrivateKey
  */
-export async function markNotificationRead(notificationId) {
+export async function markNotificationRead(notificationId, sessionKey) {
+    const proof = await signKeyChallenge(sessionKey, CHALLENGE_MARK_NOTIFICATION_READ, notificationId);
     const response = await fetch(`${FABRIC_URL}/api/fabric/notifications/${notificationId}/read`, {
-        method: 'POST'
+        method: 'POST',
+        headers: { 'Content-Type': 'application/json' },
+        body: JSON.stringify(proof)
     });
     return response.json();
 }
 
 /**
- * Get reputation from blockchain
+ * Get reputation from blockchain (signs a GET_REPUTATION challenge)
+ * @param {Object} sessionKey - Imported key from crypto.importPrivateKey
  */
-export async function getReputation(publicKeyHash) {
-    const response = await fetch(`${FABRIC_URL}/api/fabric/reputation/${publicKeyHash}`);
+export async function getReputation(sessionKey) {
+    const proof = await signKeyChallenge(sessionKey, CHALLENGE_GET_REPUTATION);
+    const response = await fetch(`${FABRIC_URL}/api/fabric/reputation?${keyProofQuery(proof)}`);
     return response.json();
 }
 
@@ -185,21 +214,23 @@ export async function verifyIntegrity(evidenceId, computedHash, passed, rejectio
 /**
  * Review evidence (for legal)
  */
-export async function reviewEvidence(evidenceId, complete, verdict) {
+export async function reviewEvidence(evidenceId, complete, verdict, justification) {
     const response = await fetch(`${FABRIC_URL}/api/fabric/legal/${evidenceId}/review`, {
         method: 'POST',
         headers: { 'Content-Type': 'application/json' },
-        body: JSON.stringify({ complete, verdict })
+        body: JSON.stringify({ complete, verdict, justification })
     });
     return response.json();
 }
 
 /**
- * Export evidence from blockchain
+ * Request a court export (needs approval by a second legal identity)
  */
-export async function exportEvidenceFromChaincode(evidenceId) {
+export async function exportEvidenceFromChaincode(evidenceId, reason) {
     const response = await fetch(`${FABRIC_URL}/api/fabric/legal/${evidenceId}/export`, {
-        method: 'POST'
+        method: 'POST',
+        headers: { 'Content-Type': 'application/json' },
+        body: JSON.stringify({ reason })
     });
     return response.json();
 }
@@ -236,10 +267,10 @@ export async function addLegalComment(evidenceId, commentId, content, courtReadi
 }
 
 /**
- * Export evidence (wrapper for chaincode export)
+ * Request export (wrapper for chaincode export request)
  */
-export async function exportEvidence(evidenceId) {
-    return exportEvidenceFromChaincode(evidenceId);
+export async function exportEvidence(evidenceId, reason) {
+    return exportEvidenceFromChaincode(evidenceId, reason);
 }
 
 /**
@@ -256,10 +287,12 @@ export async function getEvidence(evidenceId) {
 /**
  * Full evidence submission flow:
  * 1. Send file to backend (strip metadata, upload to IPFS, anchor to Sepolia)
- * 2. Submit to blockchain via Fabric Gateway
+ * 2. Sign the stripped file's hash and submit to blockchain via Fabric Gateway
  * 3. Return combined result
+ * @param {FormData} formData
+ * @param {Object} sessionKey - Imported key from crypto.importPrivateKey
  */
-export async function submitEvidenceFull(formData) {
+export async function submitEvidenceFull(formData, sessionKey) {
     // Step 1: Backend processing
     console.log('Step 1: Processing file via backend...');
     const backendResult = await submitEvidenceToBackend(formData);
@@ -270,7 +303,11 @@ export async function submitEvidenceFull(formData) {
 
     const { evidenceId, ipfsCid, fileHash, fileType, fileSize, category } = backendResult.data;
     const publicKeyHash = formData.get('publicKeyHash');
-    const signature = formData.get('signature');
+    const publicKey = formData.get('publicKey');
+
+    // Metadata stripping changes the file, so the chaincode checks a signature over the stored hash,
+    // bound to the evidence ID and CID the backend assigned
+    const signature = await signData(sessionKey.privateKey, buildSubmissionMessage(evidenceId, ipfsCid, fileHash));
 
     // Step 2: Submit to blockchain
     console.log('Step 2: Submitting to blockchain...');
@@ -284,6 +321,7 @@ export async function submitEvidenceFull(formData) {
             category: category || 'other',
             description: formData.get('description'),
             publicKeyHash,
+            publicKey,
             signature
         });
 
@@ -31,8 +31,8 @@ export async function generateKeypair() {
     const publicKeyJwk = await window.crypto.subtle.exportKey('jwk', keypair.publicKey);
     const privateKeyJwk = await window.crypto.subtle.exportKey('jwk', keypair.privateKey);
 
-    // Create public key hash (user's anonymous ID)
-    const publicKeyHash = await hashPublicKey(publicKeyJwk);
+    // Create public key hash (user's anonymous ID) - SHA-256 of the SPKI DER, as the chaincode computes it
+    const publicKeyHash = await hashPublicKey(await exportPublicKeySpki(keypair.publicKey));
 
     return {
         publicKey: publicKeyJwk,
@@ -75,7 +75,7 @@ export function downloadKeypairAsFile(keypair) {
  * Import a private key from user input and derive publicKeyHash
  * This is how users "login" - they provide their private key
  * @param {Object|string} privateKeyInput - JWK object or JSON string
- * @returns {Promise<{privateKey: CryptoKey, publicKey: CryptoKey, publicKeyHash: string}>}
+ * @returns {Promise<{privateKey: CryptoKey, publicKey: CryptoKey, publicKeySpki: string, publicKeyHash: string, legacyPublicKeyHash: string|null}>}
  */
 export async function importPrivateKey(privateKeyInput) {
     try {
@@ -85,7 +85,9 @@ export async function importPrivateKey(privateKeyInput) {
             : privateKeyInput;
 
         // If it's a full backup file, extract just the privateKey
+        let backup = null;
         if (privateKeyJwk.privateKey) {
+            backup = privateKeyJwk;
             privateKeyJwk = privateKeyJwk.privateKey;
         }
 
@@ -114,15 +116,22 @@ export async function importPrivateKey(privateKeyInput) {
             ['verify']
         );
 
-        // Compute publicKeyHash
-        const publicKeyHash = await hashPublicKey(publicKeyJwk);
+        // Compute publicKeyHash over the SPKI DER (the chaincode binds the key to this hash)
+        const publicKeySpki = await exportPublicKeySpki(publicKey);
+        const publicKeyHash = await hashPublicKey(publicKeySpki);
+
+        // Backups made before SPKI hashing carry a hash of the JWK instead
+        const storedHash = backup?.publicKeyHash;
+        const legacyPublicKeyHash = storedHash && storedHash !== publicKeyHash ? storedHash : null;
 
         return {
             privateKey,
             publicKey,
             publicKeyJwk,
             privateKeyJwk,
-            publicKeyHash
+            publicKeySpki,
+            publicKeyHash,
+            legacyPublicKeyHash
         };
     } catch (error) {
         throw new Error(`Invalid private key: ${error.message}`);
@@ -165,18 +174,63 @@ export async function signData(privateKey, data) {
     return btoa(String.fromCharCode(...new Uint8Array(signature)));
 }
 
+/**
+ * Message signed for WhistleblowerContract:SubmitEvidence (chaincode SubmissionSignedMessage):
+ * chainproof:SUBMIT:<evidenceId>:<ipfsCid>:<fileHash>
+ * The signature is public, so it binds the record it creates and cannot be replayed under another ID or CID.
+ */
+export function buildSubmissionMessage(evidenceId, ipfsCid, fileHash) {
+    return `chainproof:SUBMIT:${evidenceId}:${ipfsCid}:${fileHash}`;
+}
+
+/**
+ * Key ownership challenge actions (must match the chaincode's Challenge* constants)
+ */
+export const CHALLENGE_GET_NOTIFICATIONS = 'GET_NOTIFICATIONS';
+export const CHALLENGE_MARK_NOTIFICATION_READ = 'MARK_NOTIFICATION_READ';
+export const CHALLENGE_GET_REPUTATION = 'GET_REPUTATION';
+
+/**
+ * Sign a key ownership challenge (chaincode BuildKeyChallenge format):
+ * chainproof:<action>:<publicKeyHash>:<subject>:<challengeTimestamp>
+ * The chaincode accepts a challenge timestamp within 5 minutes of the transaction time.
+ * @param {Object} sessionKey - Imported key from importPrivateKey
+ * @param {string} action - One of the CHALLENGE_* actions
+ * @param {string} subject - Record the proof is scoped to (e.g. notification ID), '' for key-wide reads
+ * @returns {Promise<{publicKey: string, challengeTimestamp: number, signature: string}>}
+ */
+export async function signKeyChallenge(sessionKey, action, subject = '') {
+    const challengeTimestamp = Math.floor(Date.now() / 1000);
+    const message = `chainproof:${action}:${sessionKey.publicKeyHash.toLowerCase()}:${subject}:${challengeTimestamp}`;
+    const signature = await signData(sessionKey.privateKey, message);
+    return { publicKey: sessionKey.publicKeySpki, challengeTimestamp, signature };
+}
+
 // =============================================================================
 // HASHING
 // =============================================================================
 
+/**
+ * Export a public key as base64 DER SubjectPublicKeyInfo (the format the chaincode accepts)
+ * @param {CryptoKey} publicKey
+ * @returns {Promise<string>} Base64-encoded SPKI
+ */
+export async function exportPublicKeySpki(publicKey) {
+    const spki = await window.crypto.subtle.exportKey('spki', publicKey);
+    return btoa(String.fromCharCode(...new Uint8Array(spki)));
+}
+
 /**
  * Hash a public key to create the anonymous user ID
- * @param {Object} publicKeyJwk 
+ * Matches the chaincode: SHA-256 over the DER-encoded SubjectPublicKeyInfo
+ * @param {string} publicKeySpki - Base64-encoded SPKI
  * @returns {Promise<string>} Hex-encoded hash
  */
-async function hashPublicKey(publicKeyJwk) {
-    const publicKeyString = JSON.stringify(publicKeyJwk);
-    return await sha256(publicKeyString);
+async function hashPublicKey(publicKeySpki) {
+    const der = Uint8Array.from(atob(publicKeySpki), c => c.charCodeAt(0));
+    const hashBuffer = await window.crypto.subtle.digest('SHA-256', der);
+    const hashArray = Array.from(new Uint8Array(hashBuffer));
+    return hashArray.map(b => b.toString(16).padStart(2, '0')).join('');
 }
 
 /**
@@ -248,6 +302,9 @@ export default {
     importFromBackupFile,
     // Signing
     signData,
+    buildSubmissionMessage,
+    signKeyChallenge,
+    exportPublicKeySpki,
     // Hashing
     sha256,
     hashFile,